@@ -0,0 +1,202 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+// chart-templates turns ClusterRole and ValidatingWebhookConfiguration generated by controller-gen
+// into templates of the chart. Rules and webhooks of every connector are generated separately
+// into <config>/rbac/<connector> and <config>/webhook/<connector>, rules shared by all connectors
+// into <config>/rbac/common. Templates register rules and webhooks of enabled connectors only.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	commonDir   = "common"
+	roleFile    = "role.yaml"
+	webhookFile = "manifests.yaml"
+
+	header = `{{- /*
+Generated by make manifest from kubebuilder markers, do not edit.
+*/}}
+`
+	fullname = `{{ include "yandex-cloud-connectors.fullname" . }}`
+)
+
+func main() {
+	var configDir, chartDir string
+	flag.StringVar(&configDir, "config", "./config", "Directory controller-gen generated RBAC and webhooks into.")
+	flag.StringVar(&chartDir, "chart", "./helm/yandex-cloud-connectors", "Directory of the chart.")
+	flag.Parse()
+
+	if err := execute(configDir, chartDir); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func execute(configDir, chartDir string) error {
+	connectors, err := connectorsOf(filepath.Join(configDir, "rbac"))
+	if err != nil {
+		return err
+	}
+
+	role, err := roleTemplate(configDir, connectors)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(chartDir, "templates", "system", roleFile), role, 0600); err != nil {
+		return fmt.Errorf("unable to write role template: %w", err)
+	}
+
+	webhooks, err := webhookTemplate(configDir, connectors)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(chartDir, "templates", "webhook", webhookFile), webhooks, 0600); err != nil {
+		return fmt.Errorf("unable to write webhook template: %w", err)
+	}
+	return nil
+}
+
+// connectorsOf returns short names of connectors rules are generated for, in stable order.
+func connectorsOf(rbacDir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(rbacDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read generated rules: %w", err)
+	}
+	var res []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != commonDir {
+			res = append(res, entry.Name())
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func roleTemplate(configDir string, connectors []string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	out.WriteString(header)
+	out.WriteString(`---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: ` + fullname + `-connector-manager-role
+rules:
+`)
+
+	common, err := readRules(filepath.Join(configDir, "rbac", commonDir, roleFile))
+	if err != nil {
+		return nil, err
+	}
+	if err := writeList(out, common); err != nil {
+		return nil, err
+	}
+
+	for _, connector := range connectors {
+		rules, err := readRules(filepath.Join(configDir, "rbac", connector, roleFile))
+		if err != nil {
+			return nil, err
+		}
+		if err := writeConditional(out, connector, rules, len(rules)); err != nil {
+			return nil, err
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func webhookTemplate(configDir string, connectors []string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	out.WriteString(header)
+	out.WriteString(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: ` + fullname + `-validating-webhook-configuration
+webhooks:
+`)
+
+	for _, connector := range connectors {
+		webhooks, err := readWebhooks(filepath.Join(configDir, "webhook", connector, webhookFile))
+		if err != nil {
+			return nil, err
+		}
+		if err := writeConditional(out, connector, webhooks, len(webhooks)); err != nil {
+			return nil, err
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func readRules(path string) ([]rbacv1.PolicyRule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read generated rules: %w", err)
+	}
+	var role rbacv1.ClusterRole
+	if err := yaml.Unmarshal(data, &role); err != nil {
+		return nil, fmt.Errorf("unable to parse generated rules %s: %w", path, err)
+	}
+	return role.Rules, nil
+}
+
+// readWebhooks returns validating webhooks of the file, controller-gen puts mutating and
+// validating configurations into one file as separate documents.
+func readWebhooks(path string) ([]admissionregistrationv1.ValidatingWebhook, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read generated webhooks: %w", err)
+	}
+	for _, doc := range bytes.Split(data, []byte("\n---\n")) {
+		var conf admissionregistrationv1.ValidatingWebhookConfiguration
+		if err := yaml.Unmarshal(doc, &conf); err != nil {
+			return nil, fmt.Errorf("unable to parse generated webhooks %s: %w", path, err)
+		}
+		if conf.Kind == "ValidatingWebhookConfiguration" {
+			return conf.Webhooks, nil
+		}
+	}
+	return nil, nil
+}
+
+// writeConditional writes list that is rendered only if the connector is enabled.
+func writeConditional(out *bytes.Buffer, connector string, list interface{}, length int) error {
+	if length == 0 {
+		return nil
+	}
+	fmt.Fprintf(out, "{{- if include \"yandex-cloud-connectors.connectorEnabled\" (list $ %q) }}\n", connector)
+	if err := writeList(out, list); err != nil {
+		return err
+	}
+	out.WriteString("{{- end }}\n")
+	return nil
+}
+
+func writeList(out *bytes.Buffer, list interface{}) error {
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("unable to marshal list: %w", err)
+	}
+	if string(data) == "[]\n" || string(data) == "null\n" {
+		return nil
+	}
+	out.Write(data)
+	return nil
+}
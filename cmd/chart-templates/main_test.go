@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGenerated(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("rules and webhooks of connector are rendered only if it is enabled", func(t *testing.T) {
+		// Arrange
+		config, chart := t.TempDir(), t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(chart, "templates", "system"), 0700))
+		require.NoError(t, os.MkdirAll(filepath.Join(chart, "templates", "webhook"), 0700))
+		writeGenerated(t, config, "rbac/common/role.yaml", `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: connector-manager-role
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - namespaces
+  verbs:
+  - get
+`)
+		writeGenerated(t, config, "rbac/ycr/role.yaml", `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: connector-manager-role
+rules:
+- apiGroups:
+  - connectors.cloud.yandex.com
+  resources:
+  - yandexcontainerregistries
+  verbs:
+  - get
+`)
+		writeGenerated(t, config, "webhook/ycr/manifests.yaml", `
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  creationTimestamp: null
+  name: validating-webhook-configuration
+webhooks:
+- admissionReviewVersions:
+  - v1
+  clientConfig:
+    service:
+      name: webhook-service
+      namespace: system
+      path: /validate-connectors-cloud-yandex-com-v1-yandexcontainerregistry
+  failurePolicy: Fail
+  name: vyandexcontainerregistry.yandex.com
+  sideEffects: None
+`)
+
+		// Act
+		require.NoError(t, execute(config, chart))
+
+		// Assert
+		role, err := ioutil.ReadFile(filepath.Join(chart, "templates", "system", "role.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(role), `name: {{ include "yandex-cloud-connectors.fullname" . }}-connector-manager-role`)
+		common := strings.Index(string(role), "- namespaces")
+		condition := strings.Index(string(role), `{{- if include "yandex-cloud-connectors.connectorEnabled" (list $ "ycr") }}`)
+		registries := strings.Index(string(role), "- yandexcontainerregistries")
+		assert.True(t, 0 <= common && common < condition && condition < registries)
+
+		webhooks, err := ioutil.ReadFile(filepath.Join(chart, "templates", "webhook", "manifests.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(webhooks), `{{- if include "yandex-cloud-connectors.connectorEnabled" (list $ "ycr") }}
+- admissionReviewVersions:`)
+		assert.Contains(t, string(webhooks), "name: vyandexcontainerregistry.yandex.com\n  sideEffects: None\n{{- end }}\n")
+	})
+}
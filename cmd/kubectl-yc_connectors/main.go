@@ -0,0 +1,22 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/plugin"
+)
+
+// Binary is installed into PATH as kubectl-yc_connectors and invoked as "kubectl yc-connectors".
+func main() {
+	if err := plugin.Execute(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/managerconfig"
+)
+
+// loadConfig reads the config file if it is given and overrides its values with explicitly set flags.
+func loadConfig() (*managerconfig.ManagerConfig, error) {
+	cfg := managerconfig.Default()
+	if configFile != "" {
+		var err error
+		if cfg, err = managerconfig.Load(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyFlags(cfg); err != nil {
+		return nil, err
+	}
+
+	// Explicit cluster id takes precedence over any other source
+	if clusterID != "" {
+		cfg.ClusterID.Source = managerconfig.ClusterIDSourceStatic
+		cfg.ClusterID.Value = clusterID
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+//nolint:gocyclo
+func applyFlags(cfg *managerconfig.ManagerConfig) error {
+	certificate := &cfg.Webhook.Certificate
+
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "cluster-id-source":
+			cfg.ClusterID.Source = clusterIDSource
+		case "metadata-endpoint":
+			cfg.ClusterID.MetadataEndpoint = metadataEndpoint
+		case "cluster-id-namespace":
+			cfg.ClusterID.Namespace = clusterIDNamespace
+		case "cluster-id-configmap":
+			ns, name, splitErr := splitNamespacedName(clusterIDConfigMap)
+			if splitErr != nil && err == nil {
+				err = fmt.Errorf("invalid value of flag --%s: %w", f.Name, splitErr)
+			}
+			cfg.ClusterID.ConfigMap.Namespace, cfg.ClusterID.ConfigMap.Name = ns, name
+		case "cluster-id-configmap-key":
+			cfg.ClusterID.ConfigMap.Key = clusterIDConfigMapKey
+		case "metrics-bind-address":
+			cfg.Metrics.BindAddress = metricsAddr
+		case "health-probe-bind-address":
+			cfg.Health.BindAddress = probeAddr
+		case "leader-elect":
+			cfg.LeaderElection.Enabled = enableLeaderElection
+		case "rotate-webhook-certificate":
+			certificate.Rotate = rotateWebhookCertificate
+		case "webhook-namespace":
+			certificate.Namespace = webhookNamespace
+		case "webhook-service":
+			certificate.Service = webhookService
+		case "webhook-secret":
+			certificate.Secret = webhookSecret
+		case "mw":
+			certificate.MutatingConfigurations = mutatingWebhooks
+		case "vw":
+			certificate.ValidatingConfigurations = validatingWebhooks
+		case "certificate-renew-before":
+			certificate.RenewBefore.Duration = certificateRenewBefore
+		case "certificate-check-interval":
+			certificate.CheckInterval.Duration = certificateCheckInterval
+		case "tracing-endpoint":
+			cfg.Tracing.Endpoint = tracingEndpoint
+		case "tracing-insecure":
+			cfg.Tracing.Insecure = tracingInsecure
+		case "tracing-sample-ratio":
+			cfg.Tracing.SampleRatio = tracingSampleRatio
+		case "watch-namespaces":
+			cfg.WatchNamespaces = watchNamespaces
+		case "connectors":
+			cfg.SetEnabledConnectors(enabledConnectors)
+		}
+
+		for name, opts := range connectorOptions {
+			connector := cfg.Connector(name)
+			switch f.Name {
+			case name + "-workers":
+				connector.Workers = opts.Workers
+			case name + "-qps":
+				connector.QPS = opts.QPS
+			case name + "-burst":
+				connector.Burst = opts.Burst
+			default:
+				continue
+			}
+			cfg.Connectors[name] = connector
+		}
+	})
+	return err
+}
+
+func splitNamespacedName(s string) (namespace, name string, err error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected NAMESPACE/NAME, got %q", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func newCache(namespaces []string) cache.NewCacheFunc {
+	if len(namespaces) == 0 {
+		return cache.New
+	}
+	return cache.MultiNamespacedCacheBuilder(namespaces)
+}
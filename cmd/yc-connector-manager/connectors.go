@@ -0,0 +1,390 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apikey "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	apikeyconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller"
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	apikeywebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/webhook"
+	authkey "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
+	authkeyconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/controller"
+	authkeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/config"
+	authkeywebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/webhook"
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeywebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/webhook"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ycrconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ycrwebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/webhook"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	ymqwebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/webhook"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	yoswebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/webhook"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	ysaconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	ysawebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/webhook"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/managerconfig"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+)
+
+type connector struct {
+	longName       string
+	setupConnector func(opts controller.Options) error
+	setupWebhook   func() error
+	// newCollector is nil for connectors which cloud resources cannot be garbage collected
+	newCollector func() gc.Collector
+}
+
+func newConnectors(
+	log logr.Logger,
+	mgr ctrl.Manager,
+	sdk *ycsdk.SDK,
+	clusterID string,
+	cfg *managerconfig.ManagerConfig,
+	clients *awsutils.ClientCache,
+) map[string]connector {
+	return map[string]connector{
+		sakeyconfig.ShortName: {
+			longName: sakeyconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupSAKeyConnector(log, mgr, sdk, clusterID, opts)
+			},
+			setupWebhook: func() error {
+				return setupSAKeyWebhook(log, mgr, sdk, cfg.EnabledConnectors(), cfg.WatchNamespaces)
+			},
+			newCollector: func() gc.Collector {
+				return sakeyconnector.NewOrphanCollector(
+					mgr.GetClient(), sdk, clusterID, cfg.GarbageCollection.ServiceAccounts,
+				)
+			},
+		},
+		apikeyconfig.ShortName: {
+			longName: apikeyconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupAPIKeyConnector(log, mgr, sdk, clusterID, opts)
+			},
+			setupWebhook: func() error { return setupAPIKeyWebhook(log, mgr, sdk, cfg.WatchNamespaces) },
+			newCollector: func() gc.Collector {
+				return apikeyconnector.NewOrphanCollector(
+					mgr.GetClient(), sdk, clusterID, cfg.GarbageCollection.ServiceAccounts,
+				)
+			},
+		},
+		authkeyconfig.ShortName: {
+			longName: authkeyconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupAuthKeyConnector(log, mgr, sdk, clusterID, opts)
+			},
+			setupWebhook: func() error { return setupAuthKeyWebhook(log, mgr, sdk, cfg.WatchNamespaces) },
+			newCollector: func() gc.Collector {
+				return authkeyconnector.NewOrphanCollector(
+					mgr.GetClient(), sdk, clusterID, cfg.GarbageCollection.ServiceAccounts,
+				)
+			},
+		},
+		ycrconfig.ShortName: {
+			longName: ycrconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupYCRConnector(log, mgr, sdk, clusterID, opts)
+			},
+			setupWebhook: func() error { return setupYCRWebhook(log, mgr, sdk, cfg.WatchNamespaces) },
+			newCollector: func() gc.Collector {
+				return ycrconnector.NewOrphanCollector(mgr.GetClient(), sdk, clusterID, cfg.GarbageCollection.Folders)
+			},
+		},
+		ymqconfig.ShortName: {
+			longName: ymqconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupYMQConnector(log, mgr, cfg.Endpoints.MessageQueue, clusterID, clients, opts)
+			},
+			setupWebhook: func() error { return setupYMQWebhook(log, mgr, cfg.WatchNamespaces) },
+			newCollector: func() gc.Collector {
+				return ymqconnector.NewOrphanCollector(
+					mgr.GetClient(), cfg.Endpoints.MessageQueue, clients, clusterID,
+					cfg.GarbageCollection.StaticAccessKeyNames(),
+				)
+			},
+		},
+		yosconfig.ShortName: {
+			longName: yosconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupYOSConnector(log, mgr, cfg.Endpoints.ObjectStorage, clusterID, clients, opts)
+			},
+			setupWebhook: func() error {
+				return setupYOSWebhook(log, mgr, cfg.Endpoints.ObjectStorage, clients, cfg.WatchNamespaces)
+			},
+			newCollector: func() gc.Collector {
+				return yosconnector.NewOrphanCollector(
+					mgr.GetClient(), cfg.Endpoints.ObjectStorage, clients, clusterID,
+					cfg.GarbageCollection.StaticAccessKeyNames(),
+				)
+			},
+		},
+		ysaconfig.ShortName: {
+			longName: ysaconfig.LongName,
+			setupConnector: func(opts controller.Options) error {
+				return setupYSAConnector(log, mgr, sdk, clusterID, opts)
+			},
+			setupWebhook: func() error { return setupYSAWebhook(log, mgr, sdk, cfg.WatchNamespaces) },
+			newCollector: func() gc.Collector {
+				return ysaconnector.NewOrphanCollector(mgr.GetClient(), sdk, clusterID, cfg.GarbageCollection.Folders)
+			},
+		},
+	}
+}
+
+// setupConnectors sets up controllers and webhooks of enabled connectors only,
+// as well as garbage collector of their cloud resources if it is enabled.
+func setupConnectors(
+	ctx context.Context,
+	log logr.Logger,
+	mgr ctrl.Manager,
+	sdk *ycsdk.SDK,
+	clusterID string,
+	cfg *managerconfig.ManagerConfig,
+) error {
+	clients := awsutils.NewClientCache()
+	connectors := newConnectors(log, mgr, sdk, clusterID, cfg, clients)
+	enabled := cfg.EnabledConnectors()
+	var collectors []gc.Collector
+	for _, name := range enabled {
+		c := connectors[name]
+		if err := c.setupConnector(cfg.Options(name).ControllerOptions()); err != nil {
+			return fmt.Errorf("unable to set up %s connector: %w", c.longName, err)
+		}
+		if err := c.setupWebhook(); err != nil {
+			return fmt.Errorf("unable to set up %s webhook: %w", c.longName, err)
+		}
+		if c.newCollector != nil {
+			collectors = append(collectors, c.newCollector())
+		}
+	}
+
+	// Clients shared by YMQ and YOS connectors must be invalidated once their credentials change
+	if util.ContainsString(enabled, ymqconfig.ShortName) || util.ContainsString(enabled, yosconfig.ShortName) {
+		if err := clients.Watch(ctx, mgr.GetCache()); err != nil {
+			return fmt.Errorf("unable to watch credentials of shared clients: %w", err)
+		}
+	}
+
+	if cfg.GarbageCollection.Enabled && len(collectors) != 0 {
+		if err := setupGarbageCollector(log, mgr, collectors, cfg); err != nil {
+			return fmt.Errorf("unable to set up garbage collector: %w", err)
+		}
+	}
+	return nil
+}
+
+func setupGarbageCollector(
+	log logr.Logger, mgr ctrl.Manager, collectors []gc.Collector, cfg *managerconfig.ManagerConfig,
+) error {
+	log.V(1).Info("starting garbage collector")
+	// Events about orphaned resources are attached to the manager pod, they have no objects of their own
+	involved := &v1.ObjectReference{
+		Kind:       "Pod",
+		APIVersion: "v1",
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+		Name:       os.Getenv("POD_NAME"),
+	}
+	if involved.Namespace == "" {
+		involved.Namespace = cfg.Webhook.Certificate.Namespace
+	}
+	return mgr.Add(gc.NewSweeper(
+		ctrl.Log.WithName("garbage-collector"),
+		collectors,
+		mgr.GetEventRecorderFor("garbage-collector"),
+		involved,
+		cfg.GarbageCollection.Interval.Duration,
+		cfg.GarbageCollection.GracePeriod.Duration,
+		cfg.GarbageCollection.Delete,
+	))
+}
+
+func setupSAKeyConnector(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string, opts controller.Options,
+) error {
+	log.V(1).Info("starting " + sakeyconfig.ShortName + " connector")
+	sakeyReconciler := sakeyconnector.NewStaticAccessKeyReconciler(
+		ctrl.Log.WithName("connector").WithName(sakeyconfig.ShortName),
+		mgr.GetClient(),
+		mgr.GetAPIReader(),
+		sdk,
+		clusterID,
+		mgr.GetEventRecorderFor(sakeyconfig.LongName),
+	)
+	return sakeyReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupSAKeyWebhook(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, enabled []string, namespaces []string,
+) error {
+	log.V(1).Info("starting " + sakeyconfig.ShortName + " webhook")
+	validator := sakeywebhook.NewSAKeyValidator(mgr.GetClient(), sdk, enabled)
+	return webhook.RegisterValidatingHandler(mgr, &sakey.StaticAccessKey{}, webhook.ScopedToNamespaces(validator, namespaces))
+}
+
+func setupAPIKeyConnector(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string, opts controller.Options,
+) error {
+	log.V(1).Info("starting " + apikeyconfig.ShortName + " connector")
+	apikeyReconciler := apikeyconnector.NewIAMApiKeyReconciler(
+		ctrl.Log.WithName("connector").WithName(apikeyconfig.ShortName),
+		mgr.GetClient(),
+		sdk,
+		clusterID,
+		mgr.GetEventRecorderFor(apikeyconfig.LongName),
+	)
+	return apikeyReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupAPIKeyWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, namespaces []string) error {
+	log.V(1).Info("starting " + apikeyconfig.ShortName + " webhook")
+	validator := apikeywebhook.NewAPIKeyValidator(sdk)
+	return webhook.RegisterValidatingHandler(mgr, &apikey.IAMApiKey{}, webhook.ScopedToNamespaces(validator, namespaces))
+}
+
+func setupAuthKeyConnector(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string, opts controller.Options,
+) error {
+	log.V(1).Info("starting " + authkeyconfig.ShortName + " connector")
+	authkeyReconciler := authkeyconnector.NewIAMAuthorizedKeyReconciler(
+		ctrl.Log.WithName("connector").WithName(authkeyconfig.ShortName),
+		mgr.GetClient(),
+		sdk,
+		clusterID,
+		mgr.GetEventRecorderFor(authkeyconfig.LongName),
+	)
+	return authkeyReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupAuthKeyWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, namespaces []string) error {
+	log.V(1).Info("starting " + authkeyconfig.ShortName + " webhook")
+	validator := authkeywebhook.NewAuthorizedKeyValidator(sdk)
+	return webhook.RegisterValidatingHandler(
+		mgr, &authkey.IAMAuthorizedKey{}, webhook.ScopedToNamespaces(validator, namespaces),
+	)
+}
+
+func setupYCRConnector(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string, opts controller.Options,
+) error {
+	log.V(1).Info("starting " + ycrconfig.ShortName + " connector")
+	ycrReconciler := ycrconnector.NewYandexContainerRegistryReconciler(
+		ctrl.Log.WithName("connector").WithName(ycrconfig.ShortName),
+		mgr.GetClient(),
+		sdk,
+		clusterID,
+		mgr.GetEventRecorderFor(ycrconfig.LongName),
+	)
+	return ycrReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupYCRWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, namespaces []string) error {
+	log.V(1).Info("starting " + ycrconfig.ShortName + " webhook")
+	validator := ycrwebhook.NewYCRValidator(sdk)
+	return webhook.RegisterValidatingHandler(mgr, &ycr.YandexContainerRegistry{}, webhook.ScopedToNamespaces(validator, namespaces))
+}
+
+func setupYMQConnector(
+	log logr.Logger,
+	mgr ctrl.Manager,
+	endpoint, clusterID string,
+	clients *awsutils.ClientCache,
+	opts controller.Options,
+) error {
+	log.V(1).Info("starting " + ymqconfig.ShortName + " connector")
+	ymqReconciler := ymqconnector.NewYandexMessageQueueReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("connector").WithName(ymqconfig.ShortName),
+		endpoint,
+		clusterID,
+		mgr.GetEventRecorderFor(ymqconfig.LongName),
+		clients,
+	)
+	return ymqReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupYMQWebhook(log logr.Logger, mgr ctrl.Manager, namespaces []string) error {
+	log.V(1).Info("starting " + ymqconfig.ShortName + " webhook")
+	validator := ymqwebhook.NewYMQValidator(mgr.GetClient())
+	return webhook.RegisterValidatingHandler(mgr, &ymq.YandexMessageQueue{}, webhook.ScopedToNamespaces(validator, namespaces))
+}
+
+func setupYOSConnector(
+	log logr.Logger,
+	mgr ctrl.Manager,
+	endpoint, clusterID string,
+	clients *awsutils.ClientCache,
+	opts controller.Options,
+) error {
+	log.V(1).Info("starting " + yosconfig.ShortName + " connector")
+	yosReconciler, err := yosconnector.NewYandexObjectStorageReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("connector").WithName(yosconfig.ShortName),
+		endpoint,
+		clusterID,
+		mgr.GetEventRecorderFor(yosconfig.LongName),
+		clients,
+	)
+	if err != nil {
+		return err
+	}
+	return yosReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupYOSWebhook(
+	log logr.Logger, mgr ctrl.Manager, endpoint string, clients *awsutils.ClientCache, namespaces []string,
+) error {
+	log.V(1).Info("starting " + yosconfig.ShortName + " webhook")
+
+	validator, err := yoswebhook.NewYOSValidator(mgr.GetClient(), endpoint, clients)
+	if err != nil {
+		return err
+	}
+
+	return webhook.RegisterValidatingHandler(mgr, &yos.YandexObjectStorage{}, webhook.ScopedToNamespaces(validator, namespaces))
+}
+
+func setupYSAConnector(
+	log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string, opts controller.Options,
+) error {
+	log.V(1).Info("starting " + ysaconfig.ShortName + " connector")
+	ysaReconciler := ysaconnector.NewYandexServiceAccountReconciler(
+		ctrl.Log.WithName("connector").WithName(ysaconfig.ShortName),
+		mgr.GetClient(),
+		sdk,
+		clusterID,
+		mgr.GetEventRecorderFor(ysaconfig.LongName),
+	)
+	return ysaReconciler.SetupWithManager(mgr, opts)
+}
+
+func setupYSAWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, namespaces []string) error {
+	log.V(1).Info("starting " + ysaconfig.ShortName + " webhook")
+	validator := ysawebhook.NewYSAValidator(sdk)
+	return webhook.RegisterValidatingHandler(
+		mgr, &ysa.YandexServiceAccount{}, webhook.ScopedToNamespaces(validator, namespaces),
+	)
+}
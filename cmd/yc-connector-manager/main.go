@@ -7,41 +7,40 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/yandex-cloud/go-sdk/iamkey"
-
+	apikey "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	authkey "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
 	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
-	sakeyconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller"
-	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
-	sakeywebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/webhook"
 	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
-	ycrconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller"
-	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
-	ycrwebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/webhook"
 	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
-	ymqconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller"
 	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
-	ymqwebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/webhook"
 	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
-	yosconnector "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller"
 	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
-	yoswebhook "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/webhook"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/auth"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/certifier"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/clusterid"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/health"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/managerconfig"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
-	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
 
 	"github.com/go-logr/logr"
-	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -49,29 +48,75 @@ var (
 	scheme = runtime.NewScheme()
 
 	// Flag section
+	configFile             string
 	metricsAddr            string
 	enableLeaderElection   bool
 	debug                  bool
 	probeAddr              string
 	clusterID              string
+	clusterIDSource        string
+	metadataEndpoint       string
+	clusterIDNamespace     string
+	clusterIDConfigMap     string
+	clusterIDConfigMapKey  string
 	serviceAccountKeyFile  string
 	serviceAccountMetadata bool
+	keyReloadInterval      time.Duration
+	workloadTokenFile      string
+	tokenExchangeEndpoint  string
+	workloadServiceAccount string
+
+	rotateWebhookCertificate bool
+	webhookNamespace         string
+	webhookService           string
+	webhookSecret            string
+	mutatingWebhooks         util.ArgList
+	validatingWebhooks       util.ArgList
+	certificateRenewBefore   time.Duration
+	certificateCheckInterval time.Duration
+
+	tracingEndpoint    string
+	tracingInsecure    bool
+	tracingSampleRatio float64
+
+	watchNamespaces   util.ArgList
+	enabledConnectors util.ArgList
+	connectorOptions  = map[string]*config.ConnectorOptions{}
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(apikey.AddToScheme(scheme))
+	utilruntime.Must(authkey.AddToScheme(scheme))
 	utilruntime.Must(sakey.AddToScheme(scheme))
 	utilruntime.Must(ycr.AddToScheme(scheme))
 	utilruntime.Must(yos.AddToScheme(scheme))
 	utilruntime.Must(ymq.AddToScheme(scheme))
-
-	// Flag section
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.StringVar(&clusterID, "cluster-id", "", "ID of this cluster in the cloud.")
+	utilruntime.Must(ysa.AddToScheme(scheme))
+
+	// Flag section, flags that are explicitly set override values from the config file
+	defaults := managerconfig.Default()
+	flag.StringVar(&configFile, "config", "",
+		"Path to the manager config file, built-in defaults are used if not set.")
+	flag.StringVar(&metricsAddr, "metrics-bind-address", defaults.Metrics.BindAddress,
+		"The address the metric endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", defaults.Health.BindAddress,
+		"The address the probe endpoint binds to.")
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"ID of this cluster in the cloud, takes precedence over --cluster-id-source.")
+	flag.StringVar(&clusterIDSource, "cluster-id-source", defaults.ClusterID.Source,
+		"Where ID of this cluster is discovered from: static, metadata, namespace or configmap.")
+	flag.StringVar(&metadataEndpoint, "metadata-endpoint", defaults.ClusterID.MetadataEndpoint,
+		"Address of the instance metadata service used by metadata cluster id source.")
+	flag.StringVar(&clusterIDNamespace, "cluster-id-namespace", defaults.ClusterID.Namespace,
+		"Namespace which UID is used as ID of this cluster by namespace cluster id source.")
+	flag.StringVar(&clusterIDConfigMap, "cluster-id-configmap", "",
+		"ConfigMap in form namespace/name holding ID of this cluster for configmap cluster id source.")
+	flag.StringVar(&clusterIDConfigMapKey, "cluster-id-configmap-key", defaults.ClusterID.ConfigMap.Key,
+		"Key of the ConfigMap holding ID of this cluster.")
 	flag.BoolVar(
-		&enableLeaderElection, "leader-elect", false,
+		&enableLeaderElection, "leader-elect", defaults.LeaderElection.Enabled,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active connector manager.",
 	)
@@ -80,56 +125,59 @@ func init() {
 		"Path to service account key file that will be used for authorization in Yandex Cloud")
 	flag.BoolVar(&serviceAccountMetadata, "service-account-metadata", false,
 		"If true, use service account token from metadata service for authorization in Yandex Cloud")
-}
-
-func getClusterIDFromNodeMetadata(sdk *ycsdk.SDK) (string, error) {
-	ctx := context.Background()
-	instanceIDReq, err := http.NewRequestWithContext(
-		ctx,
-		"GET",
-		"http://169.254.169.254:80/latest/meta-data/instance-id",
-		nil,
-	)
-	if err != nil {
-		return "", fmt.Errorf("cannot make request to metadata: %w", err)
-	}
-
-	instanceIDResp, err := http.DefaultClient.Do(instanceIDReq)
-	if err != nil {
-		return "", fmt.Errorf("error while making request to metadata: %w", err)
-	}
-
-	if instanceIDResp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unable to get instanceID from metadata")
-	}
-
-	instanceID, err := ioutil.ReadAll(instanceIDResp.Body)
-	if err != nil {
-		return "", fmt.Errorf("cannot read instanceID from metadata: %w", err)
-	}
-
-	instance, err := sdk.Compute().Instance().Get(
-		ctx, &compute.GetInstanceRequest{
-			InstanceId: string(instanceID),
-			View:       compute.InstanceView_BASIC,
-		},
-	)
-	if err != nil {
-		return "", fmt.Errorf("unable to get instance: %w", err)
-	}
-
-	ID, ok := instance.Labels["managed-kubernetes-cluster-id"]
-	if !ok {
-		return "", fmt.Errorf("instance does not have label with cluster id")
+	flag.DurationVar(&keyReloadInterval, "service-account-key-reload-interval", 30*time.Second,
+		"How often service account key file is checked for changes, 0 disables reloading.")
+	flag.StringVar(&workloadTokenFile, "workload-identity-token-file", "",
+		"Path to projected Kubernetes service account token that is exchanged for IAM token")
+	flag.StringVar(&tokenExchangeEndpoint, "token-exchange-endpoint", auth.DefaultTokenExchangeEndpoint,
+		"Endpoint of the token exchange service used with --workload-identity-token-file.")
+	flag.StringVar(&workloadServiceAccount, "workload-identity-service-account-id", "",
+		"ID of the cloud service account federated with Kubernetes service account of the manager.")
+
+	certificate := defaults.Webhook.Certificate
+	flag.BoolVar(&rotateWebhookCertificate, "rotate-webhook-certificate", certificate.Rotate,
+		"If true, manager re-issues webhook serving certificate before it expires.")
+	flag.StringVar(&webhookNamespace, "webhook-namespace", certificate.Namespace,
+		"Namespace of the webhook service.")
+	flag.StringVar(&webhookService, "webhook-service", certificate.Service,
+		"Service that is an entrypoint for webhooks.")
+	flag.StringVar(&webhookSecret, "webhook-secret", certificate.Secret,
+		"Secret with webhook serving certificate.")
+	flag.Var(&mutatingWebhooks, "mw", "Names of mutating webhook configurations to be patched on rotation.")
+	flag.Var(&validatingWebhooks, "vw", "Names of validating webhook configurations to be patched on rotation.")
+	flag.DurationVar(&certificateRenewBefore, "certificate-renew-before", certificate.RenewBefore.Duration,
+		"How long before expiration webhook serving certificate is re-issued.")
+	flag.DurationVar(&certificateCheckInterval, "certificate-check-interval", certificate.CheckInterval.Duration,
+		"How often webhook serving certificate expiration is checked.")
+
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", defaults.Tracing.Endpoint,
+		"Address of OTLP gRPC collector traces are exported to, traces are not exported if not set.")
+	flag.BoolVar(&tracingInsecure, "tracing-insecure", defaults.Tracing.Insecure,
+		"If true, traces are exported without TLS.")
+	flag.Float64Var(&tracingSampleRatio, "tracing-sample-ratio", defaults.Tracing.SampleRatio,
+		"Share of reconciliations that are traced.")
+
+	flag.Var(&watchNamespaces, "watch-namespaces",
+		"Comma-separated list of namespaces to be watched, all namespaces are watched if not set.")
+	flag.Var(&enabledConnectors, "connectors",
+		"Comma-separated list of connectors to be enabled, all connectors are enabled if not set.")
+	for _, name := range managerconfig.KnownConnectors {
+		opts := defaults.Options(name)
+		connectorOptions[name] = &opts
+		flag.IntVar(&opts.Workers, name+"-workers", opts.Workers,
+			"Number of "+name+" objects reconciled concurrently.")
+		flag.Float64Var(&opts.QPS, name+"-qps", opts.QPS,
+			"Overall rate at which "+name+" objects are reconciled.")
+		flag.IntVar(&opts.Burst, name+"-burst", opts.Burst,
+			"Burst of the "+name+" reconciliation rate.")
 	}
-
-	if err = instanceIDResp.Body.Close(); err != nil {
-		return "", fmt.Errorf("unable to close response body: %w", err)
-	}
-
-	return ID, nil
 }
 
+// Leader election lock and its events
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
 func main() {
 	if !flag.Parsed() {
 		flag.Parse()
@@ -148,88 +196,122 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := execute(setupLog); err != nil {
+	cfg, err := loadConfig()
+	if err != nil {
+		setupLog.Error(err, "failed to load configuration")
+		os.Exit(1)
+	}
+
+	if err := execute(setupLog, cfg); err != nil {
 		setupLog.Error(err, "connector manager error")
 		os.Exit(1)
 	}
 }
 
 func validateFlags() error {
-	if serviceAccountMetadata && serviceAccountKeyFile != "" {
-		return fmt.Errorf("only one of --service-account-metadata and --service-account-key-file should be set")
+	sources := 0
+	for _, set := range []bool{serviceAccountMetadata, serviceAccountKeyFile != "", workloadTokenFile != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf(
+			"only one of --service-account-metadata, --service-account-key-file " +
+				"and --workload-identity-token-file should be set",
+		)
+	}
+	if workloadTokenFile != "" && workloadServiceAccount == "" {
+		return fmt.Errorf("--workload-identity-service-account-id must be set with --workload-identity-token-file")
+	}
+	if keyReloadInterval < 0 {
+		return fmt.Errorf("--service-account-key-reload-interval must not be negative")
 	}
 	return nil
 }
 
 //nolint:gocyclo
-func execute(log logr.Logger) error {
+func execute(log logr.Logger, cfg *managerconfig.ManagerConfig) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	sdk, err := initSDK(ctx, log)
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: "yc-connector-manager",
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set up tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(err, "unable to flush traces")
+		}
+	}()
+
+	creds, err := newCredentials(log)
 	if err != nil {
 		return err
 	}
 
-	if clusterID == "" {
-		clusterID, err = getClusterIDFromNodeMetadata(sdk)
-		if err != nil {
-			return fmt.Errorf("unable to set cluster id: %w", err)
-		}
+	sdk, err := ycsdk.Build(ctx, ycsdk.Config{Credentials: creds}, tracing.DialOptions()...)
+	if err != nil {
+		return fmt.Errorf("unable to build sdk: %w", err)
 	}
 
+	config.SetRequeueIntervals(cfg.Requeue.Normal.Duration, cfg.Requeue.Errored.Duration)
+
 	mgr, err := ctrl.NewManager(
 		ctrl.GetConfigOrDie(), ctrl.Options{
 			Scheme:                 scheme,
-			MetricsBindAddress:     metricsAddr,
-			Port:                   9443,
-			HealthProbeBindAddress: probeAddr,
-			LeaderElection:         enableLeaderElection,
-			LeaderElectionID:       "faeacf9e.cloud.yandex.com",
-			CertDir:                "/etc/yandex-cloud-connectors/certs",
+			MetricsBindAddress:     cfg.Metrics.BindAddress,
+			Port:                   cfg.Webhook.Port,
+			HealthProbeBindAddress: cfg.Health.BindAddress,
+			LeaderElection:         cfg.LeaderElection.Enabled,
+			LeaderElectionID:       cfg.LeaderElection.ID,
+			LeaseDuration:          &cfg.LeaderElection.LeaseDuration.Duration,
+			RenewDeadline:          &cfg.LeaderElection.RenewDeadline.Duration,
+			RetryPeriod:            &cfg.LeaderElection.RetryPeriod.Duration,
+			CertDir:                cfg.Webhook.CertDir,
+			NewCache:               newCache(cfg.WatchNamespaces),
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("unable to set up manager: %w", err)
 	}
 
-	if err := setupSAKeyConnector(log, mgr, sdk, clusterID); err != nil {
-		return fmt.Errorf("unable to set up %s connector: %w", sakeyconfig.LongName, err)
-	}
-	if err := setupSAKeyWebhook(log, mgr, sdk); err != nil {
-		return fmt.Errorf("unable to set up %s webhook: %w", sakeyconfig.LongName, err)
-	}
-
-	if err := setupYCRConnector(log, mgr, sdk, clusterID); err != nil {
-		return fmt.Errorf("unable to set up %s connector: %w", ycrconfig.LongName, err)
-	}
-	if err := setupYCRWebhook(log, mgr, sdk); err != nil {
-		return fmt.Errorf("unable to set up %s webhook: %w", ycrconfig.LongName, err)
+	if reloader, ok := creds.(manager.Runnable); ok && keyReloadInterval > 0 {
+		if err := mgr.Add(reloader); err != nil {
+			return fmt.Errorf("unable to set up service account key reloading: %w", err)
+		}
 	}
 
-	if err := setupYMQConnector(log, mgr); err != nil {
-		return fmt.Errorf("unable to set up %s connector: %w", ymqconfig.LongName, err)
-	}
-	if err := setupYMQWebhook(log, mgr); err != nil {
-		return fmt.Errorf("unable to set up %s webhook: %w", ymqconfig.LongName, err)
+	id, err := newClusterIDProvider(cfg, sdk, mgr.GetAPIReader()).ClusterID(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to set cluster id: %w", err)
 	}
+	log.Info("cluster id discovered", "source", cfg.ClusterID.Source, "id", id)
 
-	if err := setupYOSConnector(log, mgr); err != nil {
-		return fmt.Errorf("unable to set up %s connector: %w", yosconfig.LongName, err)
-	}
-	if err := setupYOSWebhook(log, mgr); err != nil {
-		return fmt.Errorf("unable to set up %s webhook: %w", yosconfig.LongName, err)
+	if err := setupConnectors(ctx, log, mgr, sdk, id, cfg); err != nil {
+		return err
 	}
 
 	// +kubebuilder:scaffold:builder
 
+	if cfg.Webhook.Certificate.Rotate {
+		if err := setupCertificateRotator(log, mgr, cfg); err != nil {
+			return fmt.Errorf("unable to set up webhook certificate rotator: %w", err)
+		}
+	}
+
 	log.V(1).Info("setting up health check")
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("unable to set up health check: %w", err)
 	}
 
-	log.V(1).Info("setting up readiness check")
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	log.V(1).Info("setting up readiness checks")
+	if err := setupReadinessChecks(mgr, sdk, cfg); err != nil {
 		return fmt.Errorf("unable to set up readiness check: %w", err)
 	}
 
@@ -241,108 +323,87 @@ func execute(log logr.Logger) error {
 	return nil
 }
 
-func initSDK(ctx context.Context, log logr.Logger) (*ycsdk.SDK, error) {
-	if serviceAccountMetadata {
+func newCredentials(log logr.Logger) (ycsdk.Credentials, error) {
+	switch {
+	case serviceAccountMetadata:
 		log.Info("SDK is initialized with service account token from metadata")
-		return ycsdk.Build(ctx,
-			ycsdk.Config{
-				Credentials: ycsdk.InstanceServiceAccount(),
-			})
+		return ycsdk.InstanceServiceAccount(), nil
+	case workloadTokenFile != "":
+		log.Info("SDK is initialized with Kubernetes service account token exchange")
+		return auth.NewTokenExchangeCredentials(workloadTokenFile, tokenExchangeEndpoint, workloadServiceAccount), nil
 	}
 
-	key, err := parseServiceAccountKey(serviceAccountKeyFile)
-	if err != nil {
-		return nil, err
+	if strings.TrimSpace(serviceAccountKeyFile) == "" {
+		return nil, fmt.Errorf("path to service account key file is empty")
 	}
-
-	creds, err := ycsdk.ServiceAccountKey(key)
+	creds, err := auth.NewKeyFileCredentials(
+		log.WithName("sakey-reloader"), serviceAccountKeyFile, keyReloadInterval,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Info("SDK is initialized with service account key from file")
-	return ycsdk.Build(ctx,
-		ycsdk.Config{
-			Credentials: creds,
-		})
+	return creds, nil
 }
 
-func parseServiceAccountKey(file string) (*iamkey.Key, error) {
-	trimmed := strings.TrimSpace(file)
-	if trimmed == "" {
-		return nil, fmt.Errorf("path to service account key file is empty")
+func newClusterIDProvider(
+	cfg *managerconfig.ManagerConfig, sdk *ycsdk.SDK, reader client.Reader,
+) clusterid.Provider {
+	switch cfg.ClusterID.Source {
+	case managerconfig.ClusterIDSourceStatic:
+		return clusterid.NewStaticProvider(cfg.ClusterID.Value)
+	case managerconfig.ClusterIDSourceNamespace:
+		return clusterid.NewNamespaceUIDProvider(reader, cfg.ClusterID.Namespace)
+	case managerconfig.ClusterIDSourceConfigMap:
+		return clusterid.NewConfigMapProvider(reader, types.NamespacedName{
+			Namespace: cfg.ClusterID.ConfigMap.Namespace,
+			Name:      cfg.ClusterID.ConfigMap.Name,
+		}, cfg.ClusterID.ConfigMap.Key)
+	default:
+		return clusterid.NewMetadataProvider(cfg.ClusterID.MetadataEndpoint, sdk.Compute().Instance())
 	}
-	return iamkey.ReadFromJSONFile(file)
 }
 
-func setupSAKeyConnector(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string) error {
-	log.V(1).Info("starting " + sakeyconfig.ShortName + " connector")
-	sakeyReconciler := sakeyconnector.NewStaticAccessKeyReconciler(
-		ctrl.Log.WithName("connector").WithName(sakeyconfig.ShortName),
-		mgr.GetClient(),
-		sdk,
-		clusterID,
-	)
-	return sakeyReconciler.SetupWithManager(mgr)
-}
-
-func setupSAKeyWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK) error {
-	log.V(1).Info("starting " + sakeyconfig.ShortName + " webhook")
-	validator := sakeywebhook.NewSAKeyValidator(sdk)
-	return webhook.RegisterValidatingHandler(mgr, &sakey.StaticAccessKey{}, validator)
-}
-
-func setupYCRConnector(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK, clusterID string) error {
-	log.V(1).Info("starting " + ycrconfig.ShortName + " connector")
-	ycrReconciler := ycrconnector.NewYandexContainerRegistryReconciler(
-		ctrl.Log.WithName("connector").WithName(ycrconfig.ShortName),
-		mgr.GetClient(),
-		sdk,
-		clusterID,
-	)
-	return ycrReconciler.SetupWithManager(mgr)
-}
-
-func setupYCRWebhook(log logr.Logger, mgr ctrl.Manager, sdk *ycsdk.SDK) error {
-	log.V(1).Info("starting " + ycrconfig.ShortName + " webhook")
-	validator := ycrwebhook.NewYCRValidator(sdk)
-	return webhook.RegisterValidatingHandler(mgr, &ycr.YandexContainerRegistry{}, validator)
-}
-
-func setupYMQConnector(log logr.Logger, mgr ctrl.Manager) error {
-	log.V(1).Info("starting " + ymqconfig.ShortName + " connector")
-	ymqReconciler := ymqconnector.NewYandexMessageQueueReconciler(
-		mgr.GetClient(),
-		ctrl.Log.WithName("connector").WithName(ymqconfig.ShortName),
-	)
-	return ymqReconciler.SetupWithManager(mgr)
-}
-
-func setupYMQWebhook(log logr.Logger, mgr ctrl.Manager) error {
-	log.V(1).Info("starting " + ymqconfig.ShortName + " webhook")
-	validator := ymqwebhook.NewYMQValidator(mgr.GetClient())
-	return webhook.RegisterValidatingHandler(mgr, &ymq.YandexMessageQueue{}, validator)
-}
+func setupReadinessChecks(mgr ctrl.Manager, sdk *ycsdk.SDK, cfg *managerconfig.ManagerConfig) error {
+	checks := map[string]healthz.Checker{
+		"iam-token":           health.IAMTokenCheck(sdk),
+		"webhook-certificate": health.CertificateCheck(cfg.Webhook.CertDir, certifier.TLSCertName, certifier.TLSKeyName),
+		"informer-cache":      health.CacheSyncCheck(mgr.GetCache()),
+	}
+	if cfg.IsEnabled(ymqconfig.ShortName) {
+		checks["ymq-endpoint"] = health.EndpointCheck(cfg.Endpoints.MessageQueue)
+	}
+	if cfg.IsEnabled(yosconfig.ShortName) {
+		checks["yos-endpoint"] = health.EndpointCheck(cfg.Endpoints.ObjectStorage)
+	}
 
-func setupYOSConnector(log logr.Logger, mgr ctrl.Manager) error {
-	log.V(1).Info("starting " + yosconfig.ShortName + " connector")
-	yosReconciler, err := yosconnector.NewYandexObjectStorageReconciler(
-		mgr.GetClient(),
-		ctrl.Log.WithName("connector").WithName(yosconfig.ShortName),
-	)
-	if err != nil {
-		return err
+	for name, check := range checks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			return fmt.Errorf("unable to add %s check: %w", name, err)
+		}
 	}
-	return yosReconciler.SetupWithManager(mgr)
+	return nil
 }
 
-func setupYOSWebhook(log logr.Logger, mgr ctrl.Manager) error {
-	log.V(1).Info("starting " + yosconfig.ShortName + " webhook")
-
-	validator, err := yoswebhook.NewYOSValidator(mgr.GetClient())
+func setupCertificateRotator(log logr.Logger, mgr ctrl.Manager, cfg *managerconfig.ManagerConfig) error {
+	log.V(1).Info("starting webhook certificate rotator")
+	cl, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
-		return err
-	}
-
-	return webhook.RegisterValidatingHandler(mgr, &yos.YandexObjectStorage{}, validator)
+		return fmt.Errorf("unable to create kubernetes client from config: %w", err)
+	}
+
+	return mgr.Add(certifier.NewRotator(
+		ctrl.Log.WithName("certificate-rotator"),
+		cl,
+		certifier.Config{
+			SecretName:         cfg.Webhook.Certificate.Secret,
+			ServiceName:        cfg.Webhook.Certificate.Service,
+			Namespace:          cfg.Webhook.Certificate.Namespace,
+			MutatingWebhooks:   cfg.Webhook.Certificate.MutatingConfigurations,
+			ValidatingWebhooks: cfg.Webhook.Certificate.ValidatingConfigurations,
+		},
+		cfg.Webhook.Certificate.RenewBefore.Duration,
+		cfg.Webhook.Certificate.CheckInterval.Duration,
+	))
 }
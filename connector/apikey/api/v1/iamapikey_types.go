@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMApiKeySpec defines the desired state of IAMApiKey
+type IAMApiKeySpec struct {
+	// ServiceAccountID: id of service account from which the key will be issued. Must be immutable.
+	// +kubebuilder:validation:Required
+	ServiceAccountID string `json:"serviceAccountId"`
+}
+
+// IAMApiKeyStatus defines the observed state of IAMApiKey
+type IAMApiKeyStatus struct {
+	// KeyID: id of an issued key
+	KeyID string `json:"keyId,omitempty"`
+
+	// SecretName: name of a secret containing issued key
+	// in "api-key" entry. It is always in the same namespace
+	// as the IAMApiKey.
+	SecretName string `json:"secretName,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// IAMApiKey is the Schema for the iamapikey API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=apikey
+type IAMApiKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMApiKeySpec   `json:"spec,omitempty"`
+	Status IAMApiKeyStatus `json:"status,omitempty"`
+}
+
+// IAMApiKeyList contains a list of IAMApiKey
+// +kubebuilder:object:root=true
+type IAMApiKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMApiKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMApiKey{}, &IAMApiKeyList{})
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+type IAMApiKeyAdapterSDK struct {
+	sdk *ycsdk.SDK
+}
+
+func NewIAMApiKeyAdapter(sdk *ycsdk.SDK) IAMApiKeyAdapter {
+	return &IAMApiKeyAdapterSDK{
+		sdk: sdk,
+	}
+}
+
+func (r IAMApiKeyAdapterSDK) Create(ctx context.Context, saID, description string) (*iam.CreateApiKeyResponse, error) {
+	res, err := r.sdk.IAM().ApiKey().Create(
+		ctx, &iam.CreateApiKeyRequest{
+			ServiceAccountId: saID,
+			Description:      description,
+		},
+	)
+	return res, errorhandling.NewCloudError(err, "CreateApiKey", saID)
+}
+
+func (r IAMApiKeyAdapterSDK) Read(ctx context.Context, keyID string) (*iam.ApiKey, error) {
+	res, err := r.sdk.IAM().ApiKey().Get(
+		ctx, &iam.GetApiKeyRequest{
+			ApiKeyId: keyID,
+		},
+	)
+	return res, errorhandling.NewCloudError(err, "GetApiKey", keyID)
+}
+
+func (r IAMApiKeyAdapterSDK) Delete(ctx context.Context, keyID string) error {
+	if _, err := r.sdk.IAM().ApiKey().Delete(
+		ctx, &iam.DeleteApiKeyRequest{
+			ApiKeyId: keyID,
+		},
+	); err != nil {
+		return errorhandling.NewCloudError(err, "DeleteApiKey", keyID)
+	}
+
+	return nil
+}
+
+func (r IAMApiKeyAdapterSDK) List(ctx context.Context, saID string) ([]*iam.ApiKey, error) {
+	var res []*iam.ApiKey
+	it := r.sdk.IAM().ApiKey().ApiKeyIterator(ctx, &iam.ListApiKeysRequest{ServiceAccountId: saID})
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListApiKeys", saID)
+	}
+	return res, nil
+}
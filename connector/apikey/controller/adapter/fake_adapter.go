@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type FakeIAMApiKeyAdapter struct {
+	Storage map[string]*iam.ApiKey
+	FreeID  int
+}
+
+func NewFakeIAMApiKeyAdapter() FakeIAMApiKeyAdapter {
+	return FakeIAMApiKeyAdapter{
+		Storage: map[string]*iam.ApiKey{},
+		FreeID:  0,
+	}
+}
+
+func (r *FakeIAMApiKeyAdapter) Create(_ context.Context, saID, description string) (*iam.CreateApiKeyResponse, error) {
+	res := &iam.CreateApiKeyResponse{
+		ApiKey: &iam.ApiKey{
+			Id:               strconv.Itoa(r.FreeID),
+			ServiceAccountId: saID,
+			CreatedAt:        timestamppb.Now(),
+			Description:      description,
+		},
+		Secret: "secret-" + strconv.Itoa(r.FreeID),
+	}
+	r.Storage[strconv.Itoa(r.FreeID)] = res.ApiKey
+	r.FreeID++
+	return res, nil
+}
+
+func (r *FakeIAMApiKeyAdapter) Read(_ context.Context, keyID string) (*iam.ApiKey, error) {
+	if _, ok := r.Storage[keyID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "key not found: "+keyID)
+	}
+	return r.Storage[keyID], nil
+}
+
+func (r *FakeIAMApiKeyAdapter) Delete(_ context.Context, keyID string) error {
+	if _, ok := r.Storage[keyID]; !ok {
+		return status.Errorf(codes.NotFound, "key not found: "+keyID)
+	}
+	delete(r.Storage, keyID)
+	return nil
+}
+
+func (r *FakeIAMApiKeyAdapter) List(_ context.Context, saID string) ([]*iam.ApiKey, error) {
+	list := []*iam.ApiKey{}
+	for _, key := range r.Storage {
+		if key.ServiceAccountId == saID {
+			list = append(list, key)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+)
+
+type IAMApiKeyAdapter interface {
+	Create(ctx context.Context, saID string, description string) (*iam.CreateApiKeyResponse, error)
+	Read(ctx context.Context, keyID string) (*iam.ApiKey, error)
+	Delete(ctx context.Context, keyID string) error
+	List(ctx context.Context, saID string) ([]*iam.ApiKey, error)
+}
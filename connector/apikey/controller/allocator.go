@@ -0,0 +1,99 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"go.uber.org/multierr"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	apikeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *iamAPIKeyReconciler) allocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMApiKey,
+) (_ *iam.ApiKey, err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	res, err := apikeyutils.GetAPIKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
+	)
+	if err == nil {
+		return res, nil
+	}
+	if !errorhandling.CheckConnectorErrorCode(err, apikeyconfig.ErrCodeAPIKeyNotFound) {
+		return nil, fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	response, err := r.adapter.Create(
+		ctx, object.Spec.ServiceAccountID, apikeyconfig.GetAPIKeyDescription(r.clusterID, object.Name),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create resource: %w", err)
+	}
+
+	// Cloud returns the key only once, so if it cannot be
+	// stored, key is deleted and created again next time
+	if err := secret.Put(
+		ctx, r.Client, object.Name, object.Namespace, apikeyconfig.ShortName, map[string]string{
+			apikeyconfig.SecretKey: response.Secret,
+		},
+	); err != nil {
+		err := fmt.Errorf("unable to create secret: %w", err)
+		if err2 := r.adapter.Delete(ctx, response.ApiKey.Id); err2 != nil {
+			return nil, multierr.Append(err, fmt.Errorf("unable to delete key in the cloud: %w", err2))
+		}
+		return nil, err
+	}
+
+	object.Status.SecretName = secret.Name(object.Name, apikeyconfig.ShortName)
+	if err := r.Client.Update(ctx, object); err != nil {
+		return nil, fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("successful")
+	return response.ApiKey, nil
+}
+
+func (r *iamAPIKeyReconciler) deallocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMApiKey,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if err := secret.Remove(ctx, r.Client, object.Name, object.Namespace, apikeyconfig.ShortName); err != nil {
+		return fmt.Errorf("unable to delete secret: %w", err)
+	}
+
+	res, err := apikeyutils.GetAPIKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, apikeyconfig.ErrCodeAPIKeyNotFound) {
+			log.Info("already deleted")
+			return nil
+		}
+		return fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	if err := r.adapter.Delete(ctx, res.Id); err != nil {
+		return fmt.Errorf("unable to delete resource: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+)
+
+func TestAllocate(t *testing.T) {
+	t.Run(
+		"allocate on empty cloud creates resource", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+			var secret v1.Secret
+			require.NoError(t, cl.Get(ctx, client.ObjectKey{
+				Namespace: "default",
+				Name:      obj.Status.SecretName,
+			}, &secret))
+
+			// Assert
+			require.Len(t, lst, 1)
+			assert.Equal(t, "sukhov", lst[0].ServiceAccountId)
+			assert.Equal(t, apikeyconfig.GetAPIKeyDescription("test-cluster", "obj"), lst[0].Description)
+			assert.Equal(t, lst[0].Id, res.Id)
+			assert.NotEmpty(t, secret.Data[apikeyconfig.SecretKey])
+		},
+	)
+
+	t.Run(
+		"allocate on existing resource returns it", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res1, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+
+			// Act
+			res2, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Equal(t, res1.Id, res2.Id)
+		},
+	)
+}
+
+func TestDeallocate(t *testing.T) {
+	t.Run(
+		"deallocate deletes resource and secret", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			_, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+
+			// Act
+			require.NoError(t, rc.deallocateResource(ctx, log, &obj))
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+			var secret v1.Secret
+			err = cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: obj.Status.SecretName}, &secret)
+
+			// Assert
+			assert.Empty(t, lst)
+			assert.Error(t, err)
+		},
+	)
+}
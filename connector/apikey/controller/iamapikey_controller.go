@@ -0,0 +1,153 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller/adapter"
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// iamAPIKeyReconciler reconciles an IAMApiKey object
+type iamAPIKeyReconciler struct {
+	client.Client
+	adapter   adapter.IAMApiKeyAdapter
+	log       logr.Logger
+	clusterID string
+	recorder  record.EventRecorder
+}
+
+func NewIAMApiKeyReconciler(log logr.Logger, cl client.Client,
+	sdk *ycsdk.SDK, clusterID string, recorder record.EventRecorder) *iamAPIKeyReconciler {
+	return &iamAPIKeyReconciler{
+		Client:    cl,
+		adapter:   adapter.NewIAMApiKeyAdapter(sdk),
+		log:       log,
+		clusterID: clusterID,
+		recorder:  recorder,
+	}
+}
+
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=iamapikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=iamapikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=iamapikeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *iamAPIKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("name", req.NamespacedName)
+	log.V(1).Info("started reconciliation")
+
+	// Try to retrieve object from k8s
+	var object connectorsv1.IAMApiKey
+	if err := r.Get(ctx, req.NamespacedName, &object); err != nil {
+		// This outcome signifies that we just cannot find object, that is OK,
+		// we just never want to reconcile it again unless triggered externally.
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("object not found in k8s, reconciliation not possible")
+			return config.GetNeverResult()
+		}
+
+		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
+	}
+
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Update)
+	if err != nil {
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
+	}
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
+	}
+
+	// If object must be currently finalized, do it and quit
+	if phase.MustBeFinalized(&object.ObjectMeta, apikeyconfig.FinalizerName) {
+		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
+		}
+		return config.GetNormalResult()
+	}
+
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *iamAPIKeyReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMApiKey,
+) error {
+	if err := phase.RegisterFinalizer(
+		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, object, apikeyconfig.FinalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to register finalizer: %w", err)
+	}
+
+	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), object)
+	if err != nil {
+		return fmt.Errorf("unable to allocate resource: %w", err)
+	}
+
+	if err := r.updateStatus(ctx, log.WithName("update-status"), object, res); err != nil {
+		return fmt.Errorf("unable to update status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *iamAPIKeyReconciler) finalize(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMApiKey,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object)
+	}); err != nil {
+		return fmt.Errorf("unable to deallocate resource: %w", err)
+	}
+
+	if err := phase.DeregisterFinalizer(
+		ctx, r.Client, log.WithName("deregister-finalizer"), &object.ObjectMeta, object, apikeyconfig.FinalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to deregister finalizer: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *iamAPIKeyReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&connectorsv1.IAMApiKey{}).
+		WithOptions(opts).
+		Complete(tracing.Reconciler(apikeyconfig.LongName, r))
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller/adapter"
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// orphanCollector finds API keys described as issued for this cluster that have
+// no IAMApiKey object with the same name for the same service account.
+type orphanCollector struct {
+	cl              client.Reader
+	adapter         adapter.IAMApiKeyAdapter
+	clusterID       string
+	serviceAccounts []string
+}
+
+// NewOrphanCollector creates collector that looks through keys of the given service
+// accounts and service accounts of all existing IAMApiKey objects.
+func NewOrphanCollector(cl client.Reader, sdk *ycsdk.SDK, clusterID string, serviceAccounts []string) gc.Collector {
+	return &orphanCollector{
+		cl:              cl,
+		adapter:         adapter.NewIAMApiKeyAdapter(sdk),
+		clusterID:       clusterID,
+		serviceAccounts: serviceAccounts,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return apikeyconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.IAMApiKeyList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	serviceAccounts := append([]string{}, r.serviceAccounts...)
+	backed := map[string]bool{}
+	for _, object := range objects.Items {
+		if !util.ContainsString(serviceAccounts, object.Spec.ServiceAccountID) {
+			serviceAccounts = append(serviceAccounts, object.Spec.ServiceAccountID)
+		}
+		backed[object.Spec.ServiceAccountID+"/"+object.Name] = true
+	}
+
+	var res []gc.Resource
+	for _, sa := range serviceAccounts {
+		lst, err := r.adapter.List(ctx, sa)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list keys of service account %s: %w", sa, err)
+		}
+		for _, key := range lst {
+			cluster, name, ok := config.ParseCloudDescription(key.Description)
+			if !ok || cluster != r.clusterID || backed[sa+"/"+name] {
+				continue
+			}
+			res = append(res, gc.Resource{ID: key.Id, Name: name, Location: sa})
+		}
+	}
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	return r.adapter.Delete(ctx, resource.ID)
+}
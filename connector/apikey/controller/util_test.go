@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller/adapter"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+func setup(t *testing.T) (
+	context.Context,
+	logr.Logger,
+	client.Client,
+	adapter.IAMApiKeyAdapter,
+	iamAPIKeyReconciler,
+) {
+	t.Helper()
+	ad := adapter.NewFakeIAMApiKeyAdapter()
+	cl := k8sfake.NewFakeClient()
+	log := logrfake.NewFakeLogger(t)
+	return context.Background(), log, cl, &ad, iamAPIKeyReconciler{
+		cl,
+		&ad,
+		log,
+		"test-cluster",
+		record.NewFakeRecorder(10),
+	}
+}
+
+func createObject(saID, metaName, namespace string) connectorsv1.IAMApiKey {
+	return connectorsv1.IAMApiKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      metaName,
+		},
+		Spec: connectorsv1.IAMApiKeySpec{
+			ServiceAccountID: saID,
+		},
+	}
+}
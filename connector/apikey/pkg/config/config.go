@@ -0,0 +1,23 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package config
+
+import (
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+const (
+	FinalizerName = "finalizer.apikey.connectors.cloud.yandex.com"
+	LongName      = "IAMApiKey"
+	ShortName     = "apikey"
+
+	ErrCodeAPIKeyNotFound = "yc.apikey.not-found"
+
+	// SecretKey is the entry of the secret holding the key itself
+	SecretKey = "api-key"
+)
+
+func GetAPIKeyDescription(clusterName, name string) string {
+	return config.GetCloudDescription(clusterName, name)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/validate-connectors-cloud-yandex-com-v1-iamapikey,mutating=false,failurePolicy=fail,sideEffects=None,groups=connectors.cloud.yandex.com,resources=iamapikeys,verbs=create;update;delete,versions=v1,name=viamapikey.yandex.com,admissionReviewVersions=v1
+
+type APIKeyValidator struct {
+	sdk *ycsdk.SDK
+}
+
+func NewAPIKeyValidator(sdk *ycsdk.SDK) webhook.Validator {
+	return &APIKeyValidator{sdk: sdk}
+}
+
+func (r *APIKeyValidator) ValidateCreation(ctx context.Context, log logr.Logger, obj runtime.Object) error {
+	casted := obj.(*v1.IAMApiKey)
+
+	log.Info("validate create", "name", util.NamespacedName(casted))
+
+	if _, err := r.sdk.IAM().ServiceAccount().Get(
+		ctx, &iam.GetServiceAccountRequest{
+			ServiceAccountId: casted.Spec.ServiceAccountID,
+		},
+	); err != nil {
+		if errorhandling.CheckRPCErrorNotFound(err) {
+			return webhook.NewValidationErrorf(
+				"service account cannot be found in the cloud: %s",
+				casted.Spec.ServiceAccountID,
+			)
+		}
+		return fmt.Errorf("unable to get service account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyValidator) ValidateUpdate(_ context.Context, log logr.Logger, current, old runtime.Object) error {
+	castedOld, castedCurrent := old.(*v1.IAMApiKey), current.(*v1.IAMApiKey)
+
+	log.Info("validate update", "name", util.NamespacedName(castedCurrent))
+
+	if castedCurrent.Spec.ServiceAccountID != castedOld.Spec.ServiceAccountID {
+		return webhook.NewValidationErrorf(
+			"bound service account must be immutable, was changed from %s to %s",
+			castedOld.Spec.ServiceAccountID,
+			castedCurrent.Spec.ServiceAccountID,
+		)
+	}
+
+	return nil
+}
+
+func (r *APIKeyValidator) ValidateDeletion(_ context.Context, log logr.Logger, obj runtime.Object) error {
+	log.Info("validate delete", "name", util.NamespacedName(obj.(*v1.IAMApiKey)))
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+func setupValidation(t *testing.T) (context.Context, webhook.Validator, logr.Logger) {
+	t.Helper()
+	return context.TODO(), &APIKeyValidator{}, logrfake.NewFakeLogger(t)
+}
+
+func TestUpdateValidation(t *testing.T) {
+	t.Run("no-change-is-valid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.IAMApiKey{
+			Spec: v1.IAMApiKeySpec{ServiceAccountID: "sukhov"},
+		}
+		current := v1.IAMApiKey{
+			Spec: v1.IAMApiKeySpec{ServiceAccountID: "sukhov"},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("service-account-ID-change-is-invalid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.IAMApiKey{
+			Spec: v1.IAMApiKeySpec{ServiceAccountID: "sukhov"},
+		}
+		current := v1.IAMApiKey{
+			Spec: v1.IAMApiKeySpec{ServiceAccountID: "abdullah"},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMAuthorizedKeySpec defines the desired state of IAMAuthorizedKey
+type IAMAuthorizedKeySpec struct {
+	// ServiceAccountID: id of service account from which the key will be issued. Must be immutable.
+	// +kubebuilder:validation:Required
+	ServiceAccountID string `json:"serviceAccountId"`
+
+	// KeyAlgorithm: algorithm of the key pair, RSA_2048 by default. Must be immutable.
+	// +optional
+	// +kubebuilder:validation:Enum=RSA_2048;RSA_4096
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+}
+
+// IAMAuthorizedKeyStatus defines the observed state of IAMAuthorizedKey
+type IAMAuthorizedKeyStatus struct {
+	// KeyID: id of an issued key
+	KeyID string `json:"keyId,omitempty"`
+
+	// SecretName: name of a secret containing issued key
+	// in "key.json" entry. It is always in the same namespace
+	// as the IAMAuthorizedKey.
+	SecretName string `json:"secretName,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// IAMAuthorizedKey is the Schema for the iamauthorizedkey API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=authkey
+type IAMAuthorizedKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMAuthorizedKeySpec   `json:"spec,omitempty"`
+	Status IAMAuthorizedKeyStatus `json:"status,omitempty"`
+}
+
+// IAMAuthorizedKeyList contains a list of IAMAuthorizedKey
+// +kubebuilder:object:root=true
+type IAMAuthorizedKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMAuthorizedKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMAuthorizedKey{}, &IAMAuthorizedKeyList{})
+}
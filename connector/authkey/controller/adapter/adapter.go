@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+type IAMAuthorizedKeyAdapterSDK struct {
+	sdk *ycsdk.SDK
+}
+
+func NewIAMAuthorizedKeyAdapter(sdk *ycsdk.SDK) IAMAuthorizedKeyAdapter {
+	return &IAMAuthorizedKeyAdapterSDK{
+		sdk: sdk,
+	}
+}
+
+func (r IAMAuthorizedKeyAdapterSDK) Create(
+	ctx context.Context, saID, description string, algorithm iam.Key_Algorithm,
+) (*iam.CreateKeyResponse, error) {
+	res, err := r.sdk.IAM().Key().Create(
+		ctx, &iam.CreateKeyRequest{
+			ServiceAccountId: saID,
+			Description:      description,
+			KeyAlgorithm:     algorithm,
+		},
+	)
+	return res, errorhandling.NewCloudError(err, "CreateKey", saID)
+}
+
+func (r IAMAuthorizedKeyAdapterSDK) Read(ctx context.Context, keyID string) (*iam.Key, error) {
+	res, err := r.sdk.IAM().Key().Get(
+		ctx, &iam.GetKeyRequest{
+			KeyId: keyID,
+		},
+	)
+	return res, errorhandling.NewCloudError(err, "GetKey", keyID)
+}
+
+func (r IAMAuthorizedKeyAdapterSDK) Delete(ctx context.Context, keyID string) error {
+	if _, err := r.sdk.IAM().Key().Delete(
+		ctx, &iam.DeleteKeyRequest{
+			KeyId: keyID,
+		},
+	); err != nil {
+		return errorhandling.NewCloudError(err, "DeleteKey", keyID)
+	}
+
+	return nil
+}
+
+func (r IAMAuthorizedKeyAdapterSDK) List(ctx context.Context, saID string) ([]*iam.Key, error) {
+	var res []*iam.Key
+	it := r.sdk.IAM().Key().KeyIterator(ctx, &iam.ListKeysRequest{ServiceAccountId: saID})
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListKeys", saID)
+	}
+	return res, nil
+}
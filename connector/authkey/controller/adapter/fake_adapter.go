@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type FakeIAMAuthorizedKeyAdapter struct {
+	Storage map[string]*iam.Key
+	FreeID  int
+}
+
+func NewFakeIAMAuthorizedKeyAdapter() FakeIAMAuthorizedKeyAdapter {
+	return FakeIAMAuthorizedKeyAdapter{
+		Storage: map[string]*iam.Key{},
+		FreeID:  0,
+	}
+}
+
+func (r *FakeIAMAuthorizedKeyAdapter) Create(
+	_ context.Context, saID, description string, algorithm iam.Key_Algorithm,
+) (*iam.CreateKeyResponse, error) {
+	res := &iam.CreateKeyResponse{
+		Key: &iam.Key{
+			Id:           strconv.Itoa(r.FreeID),
+			Subject:      &iam.Key_ServiceAccountId{ServiceAccountId: saID},
+			CreatedAt:    timestamppb.Now(),
+			Description:  description,
+			KeyAlgorithm: algorithm,
+			PublicKey:    "public-" + strconv.Itoa(r.FreeID),
+		},
+		PrivateKey: "private-" + strconv.Itoa(r.FreeID),
+	}
+	r.Storage[strconv.Itoa(r.FreeID)] = res.Key
+	r.FreeID++
+	return res, nil
+}
+
+func (r *FakeIAMAuthorizedKeyAdapter) Read(_ context.Context, keyID string) (*iam.Key, error) {
+	if _, ok := r.Storage[keyID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "key not found: "+keyID)
+	}
+	return r.Storage[keyID], nil
+}
+
+func (r *FakeIAMAuthorizedKeyAdapter) Delete(_ context.Context, keyID string) error {
+	if _, ok := r.Storage[keyID]; !ok {
+		return status.Errorf(codes.NotFound, "key not found: "+keyID)
+	}
+	delete(r.Storage, keyID)
+	return nil
+}
+
+func (r *FakeIAMAuthorizedKeyAdapter) List(_ context.Context, saID string) ([]*iam.Key, error) {
+	list := []*iam.Key{}
+	for _, key := range r.Storage {
+		if key.GetServiceAccountId() == saID {
+			list = append(list, key)
+		}
+	}
+	return list, nil
+}
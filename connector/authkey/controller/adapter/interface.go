@@ -0,0 +1,17 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+)
+
+type IAMAuthorizedKeyAdapter interface {
+	Create(ctx context.Context, saID, description string, algorithm iam.Key_Algorithm) (*iam.CreateKeyResponse, error)
+	Read(ctx context.Context, keyID string) (*iam.Key, error)
+	Delete(ctx context.Context, keyID string) error
+	List(ctx context.Context, saID string) ([]*iam.Key, error)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-sdk/iamkey"
+	"go.uber.org/multierr"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
+	authkeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/config"
+	authkeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *iamAuthorizedKeyReconciler) allocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMAuthorizedKey,
+) (_ *iam.Key, err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	res, err := authkeyutils.GetAuthorizedKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
+	)
+	if err == nil {
+		return res, nil
+	}
+	if !errorhandling.CheckConnectorErrorCode(err, authkeyconfig.ErrCodeAuthorizedKeyNotFound) {
+		return nil, fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	algorithm := object.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = authkeyconfig.DefaultKeyAlgorithm
+	}
+	response, err := r.adapter.Create(
+		ctx, object.Spec.ServiceAccountID, authkeyconfig.GetAuthorizedKeyDescription(r.clusterID, object.Name),
+		iam.Key_Algorithm(iam.Key_Algorithm_value[algorithm]),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create resource: %w", err)
+	}
+
+	keyFile, err := json.Marshal(iamkey.New(response))
+	if err != nil {
+		err := fmt.Errorf("unable to marshal key: %w", err)
+		if err2 := r.adapter.Delete(ctx, response.Key.Id); err2 != nil {
+			return nil, multierr.Append(err, fmt.Errorf("unable to delete key in the cloud: %w", err2))
+		}
+		return nil, err
+	}
+
+	// Cloud returns the key only once, so if it cannot be
+	// stored, key is deleted and created again next time
+	if err := secret.Put(
+		ctx, r.Client, object.Name, object.Namespace, authkeyconfig.ShortName, map[string]string{
+			authkeyconfig.SecretKey: string(keyFile),
+		},
+	); err != nil {
+		err := fmt.Errorf("unable to create secret: %w", err)
+		if err2 := r.adapter.Delete(ctx, response.Key.Id); err2 != nil {
+			return nil, multierr.Append(err, fmt.Errorf("unable to delete key in the cloud: %w", err2))
+		}
+		return nil, err
+	}
+
+	object.Status.SecretName = secret.Name(object.Name, authkeyconfig.ShortName)
+	if err := r.Client.Update(ctx, object); err != nil {
+		return nil, fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("successful")
+	return response.Key, nil
+}
+
+func (r *iamAuthorizedKeyReconciler) deallocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMAuthorizedKey,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if err := secret.Remove(ctx, r.Client, object.Name, object.Namespace, authkeyconfig.ShortName); err != nil {
+		return fmt.Errorf("unable to delete secret: %w", err)
+	}
+
+	res, err := authkeyutils.GetAuthorizedKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, authkeyconfig.ErrCodeAuthorizedKeyNotFound) {
+			log.Info("already deleted")
+			return nil
+		}
+		return fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	if err := r.adapter.Delete(ctx, res.Id); err != nil {
+		return fmt.Errorf("unable to delete resource: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *iamAuthorizedKeyReconciler) updateStatus(
+	ctx context.Context, log logr.Logger, object *connectorsv1.IAMAuthorizedKey, res *iam.Key,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "update-status")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if object.Status.KeyID == res.Id {
+		return nil
+	}
+
+	object.Status.KeyID = res.Id
+	if err := r.Client.Update(ctx, object); err != nil {
+		return fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
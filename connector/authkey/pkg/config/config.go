@@ -0,0 +1,26 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package config
+
+import (
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+const (
+	FinalizerName = "finalizer.authkey.connectors.cloud.yandex.com"
+	LongName      = "IAMAuthorizedKey"
+	ShortName     = "authkey"
+
+	ErrCodeAuthorizedKeyNotFound = "yc.authkey.not-found"
+
+	// SecretKey is the entry of the secret holding the key in the format of
+	// authorized key file, the one accepted by iamkey.ReadFromJSONFile
+	SecretKey = "key.json"
+
+	DefaultKeyAlgorithm = "RSA_2048"
+)
+
+func GetAuthorizedKeyDescription(clusterName, name string) string {
+	return config.GetCloudDescription(clusterName, name)
+}
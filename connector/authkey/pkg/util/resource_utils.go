@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/controller/adapter"
+	authkeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+// GetAuthorizedKey finds key by its id, or by description if the id has not been recorded yet.
+func GetAuthorizedKey(
+	ctx context.Context, keyID, saID, clusterName, name string, ad adapter.IAMAuthorizedKeyAdapter,
+) (*iam.Key, error) {
+	if keyID != "" {
+		res, err := ad.Read(ctx, keyID)
+		if err == nil {
+			return res, nil
+		}
+		if !errorhandling.CheckRPCErrorNotFound(err) {
+			return nil, fmt.Errorf("cannot get resource from cloud: %w", err)
+		}
+	}
+
+	lst, err := ad.List(ctx, saID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list resources in cloud: %w", err)
+	}
+	for _, res := range lst {
+		if res.Description == authkeyconfig.GetAuthorizedKeyDescription(clusterName, name) {
+			return res, nil
+		}
+	}
+
+	return nil, errorhandling.New("unable to find resource in the cloud", authkeyconfig.ErrCodeAuthorizedKeyNotFound, nil)
+}
@@ -5,13 +5,84 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
 // StaticAccessKeySpec defines the desired state of StaticAccessKeySpec
 type StaticAccessKeySpec struct {
-	// ServiceAccountID: id of service account from which the key will be issued. Must be immutable.
+	// ServiceAccountID: id of service account from which the key will be issued.
+	// Exactly one of ServiceAccountID and ServiceAccountRef must be set. Must be immutable.
+	// +optional
+	ServiceAccountID string `json:"serviceAccountId,omitempty"`
+
+	// ServiceAccountRef: YandexServiceAccount from which the key will be issued once it is ready.
+	// Must be immutable.
+	// +optional
+	ServiceAccountRef *reference.ObjectReference `json:"serviceAccountRef,omitempty"`
+
+	// Rotation: if set, key is periodically reissued and secret is updated with the new one.
+	// +optional
+	Rotation *StaticAccessKeyRotation `json:"rotation,omitempty"`
+
+	// SecretTemplate: additional entries of the secret, rendered from the issued key.
+	// Entries "key" and "secret" are always present.
+	// +optional
+	SecretTemplate *StaticAccessKeySecretTemplate `json:"secretTemplate,omitempty"`
+}
+
+// StaticAccessKeyRotation defines how often the key is reissued
+type StaticAccessKeyRotation struct {
+	// Period: age of the key after which it is reissued, e.g. 2160h for 90 days
 	// +kubebuilder:validation:Required
-	ServiceAccountID string `json:"serviceAccountId"`
+	Period metav1.Duration `json:"period"`
+
+	// Overlap: time during which the previous key stays valid after it was replaced in the secret,
+	// so that its users can pick up the new one. Must be less than period.
+	// +optional
+	Overlap metav1.Duration `json:"overlap,omitempty"`
+}
+
+// StaticAccessKeySecretTemplate defines additional entries of the secret
+type StaticAccessKeySecretTemplate struct {
+	// Formats: predefined sets of entries. "env" adds AWS_* environment variables,
+	// "aws-credentials" adds "credentials" and "config" files of AWS CLI,
+	// "s3cmd" adds ".s3cfg" file and "rclone" adds "rclone.conf" file.
+	// +optional
+	Formats []string `json:"formats,omitempty"`
+
+	// Endpoint: host of object storage written into rendered entries, storage.yandexcloud.net by default
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region: region written into rendered entries, ru-central1 by default
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Profile: name of the profile in rendered files, "default" by default
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// Data: user-defined entries. Values are Go templates with fields
+	// .KeyID, .Secret, .Endpoint, .Region and .Profile, they override entries of formats.
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// StaticAccessKeyRotationRecord describes one rotation of the key
+type StaticAccessKeyRotationRecord struct {
+	// KeyID: id of the key issued by the rotation
+	KeyID string `json:"keyId"`
+
+	// PreviousKeyID: id of the key replaced by the rotation
+	PreviousKeyID string `json:"previousKeyId"`
+
+	// RotatedAt: time when the secret was updated with the new key
+	RotatedAt metav1.Time `json:"rotatedAt"`
+
+	// PreviousKeyDeletedAt: time when the replaced key was deleted in the cloud
+	// +optional
+	PreviousKeyDeletedAt *metav1.Time `json:"previousKeyDeletedAt,omitempty"`
 }
 
 // StaticAccessKeyStatus defines the observed state of StaticAccessKey
@@ -19,10 +90,33 @@ type StaticAccessKeyStatus struct {
 	// KeyID: id of an issued key
 	KeyID string `json:"keyId,omitempty"`
 
+	// ServiceAccountID: id of service account resolved from ServiceAccountRef
+	// +optional
+	ServiceAccountID string `json:"serviceAccountId,omitempty"`
+
 	// SecretRef: reference to a secret containing
 	// issued key values. It is always in the same
 	// namespace as the StaticAccessKey.
 	SecretName string `json:"secretName,omitempty"`
+
+	// PreviousKeyID: id of the key replaced by the latest rotation
+	// that stays valid until PreviousKeyDeleteAt
+	// +optional
+	PreviousKeyID string `json:"previousKeyId,omitempty"`
+
+	// PreviousKeyDeleteAt: time after which the previous key is deleted
+	// +optional
+	PreviousKeyDeleteAt *metav1.Time `json:"previousKeyDeleteAt,omitempty"`
+
+	// RotationHistory: latest rotations of the key, the most recent one is the last
+	// +optional
+	RotationHistory []StaticAccessKeyRotationRecord `json:"rotationHistory,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // StaticAccessKey is the Schema for the staticaccesskey API
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StaticAccessKeyGrantSpec defines which objects of other namespaces may use keys of the grant's namespace
+type StaticAccessKeyGrantSpec struct {
+	// KeyNames: names of StaticAccessKeys in the namespace of the grant that may be used, all keys if empty
+	// +optional
+	KeyNames []string `json:"keyNames,omitempty"`
+
+	// From: objects that may use the keys
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	From []StaticAccessKeyGrantFrom `json:"from"`
+}
+
+// StaticAccessKeyGrantFrom defines objects that may use the keys
+type StaticAccessKeyGrantFrom struct {
+	// Namespace: namespace of the objects
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Kind: kind of the objects, e.g. YandexObjectStorage, objects of any kind if empty
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// StaticAccessKeyGrant is the Schema for the staticaccesskeygrants API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=sakeygrant
+type StaticAccessKeyGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StaticAccessKeyGrantSpec `json:"spec,omitempty"`
+}
+
+// StaticAccessKeyGrantList contains a list of StaticAccessKeyGrant
+// +kubebuilder:object:root=true
+type StaticAccessKeyGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StaticAccessKeyGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StaticAccessKeyGrant{}, &StaticAccessKeyGrantList{})
+}
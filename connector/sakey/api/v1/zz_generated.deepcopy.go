@@ -31,7 +31,10 @@ THE SOFTWARE.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -39,8 +42,8 @@ func (in *StaticAccessKey) DeepCopyInto(out *StaticAccessKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKey.
@@ -61,6 +64,104 @@ func (in *StaticAccessKey) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyGrant) DeepCopyInto(out *StaticAccessKeyGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyGrant.
+func (in *StaticAccessKeyGrant) DeepCopy() *StaticAccessKeyGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticAccessKeyGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyGrantFrom) DeepCopyInto(out *StaticAccessKeyGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyGrantFrom.
+func (in *StaticAccessKeyGrantFrom) DeepCopy() *StaticAccessKeyGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyGrantList) DeepCopyInto(out *StaticAccessKeyGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StaticAccessKeyGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyGrantList.
+func (in *StaticAccessKeyGrantList) DeepCopy() *StaticAccessKeyGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticAccessKeyGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyGrantSpec) DeepCopyInto(out *StaticAccessKeyGrantSpec) {
+	*out = *in
+	if in.KeyNames != nil {
+		in, out := &in.KeyNames, &out.KeyNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]StaticAccessKeyGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyGrantSpec.
+func (in *StaticAccessKeyGrantSpec) DeepCopy() *StaticAccessKeyGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAccessKeyList) DeepCopyInto(out *StaticAccessKeyList) {
 	*out = *in
@@ -93,9 +194,88 @@ func (in *StaticAccessKeyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyRotation) DeepCopyInto(out *StaticAccessKeyRotation) {
+	*out = *in
+	out.Period = in.Period
+	out.Overlap = in.Overlap
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyRotation.
+func (in *StaticAccessKeyRotation) DeepCopy() *StaticAccessKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeyRotationRecord) DeepCopyInto(out *StaticAccessKeyRotationRecord) {
+	*out = *in
+	in.RotatedAt.DeepCopyInto(&out.RotatedAt)
+	if in.PreviousKeyDeletedAt != nil {
+		in, out := &in.PreviousKeyDeletedAt, &out.PreviousKeyDeletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyRotationRecord.
+func (in *StaticAccessKeyRotationRecord) DeepCopy() *StaticAccessKeyRotationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeyRotationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAccessKeySecretTemplate) DeepCopyInto(out *StaticAccessKeySecretTemplate) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeySecretTemplate.
+func (in *StaticAccessKeySecretTemplate) DeepCopy() *StaticAccessKeySecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAccessKeySecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAccessKeySpec) DeepCopyInto(out *StaticAccessKeySpec) {
 	*out = *in
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(reference.ObjectReference)
+		**out = **in
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(StaticAccessKeyRotation)
+		**out = **in
+	}
+	if in.SecretTemplate != nil {
+		in, out := &in.SecretTemplate, &out.SecretTemplate
+		*out = new(StaticAccessKeySecretTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeySpec.
@@ -111,6 +291,24 @@ func (in *StaticAccessKeySpec) DeepCopy() *StaticAccessKeySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAccessKeyStatus) DeepCopyInto(out *StaticAccessKeyStatus) {
 	*out = *in
+	if in.PreviousKeyDeleteAt != nil {
+		in, out := &in.PreviousKeyDeleteAt, &out.PreviousKeyDeleteAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RotationHistory != nil {
+		in, out := &in.RotationHistory, &out.RotationHistory
+		*out = make([]StaticAccessKeyRotationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAccessKeyStatus.
@@ -8,6 +8,8 @@ import (
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 )
 
 type StaticAccessKeyAdapterSDK struct {
@@ -23,20 +25,22 @@ func NewStaticAccessKeyAdapter(sdk *ycsdk.SDK) StaticAccessKeyAdapter {
 func (r StaticAccessKeyAdapterSDK) Create(
 	ctx context.Context, saID, description string,
 ) (*awscompatibility.CreateAccessKeyResponse, error) {
-	return r.sdk.IAM().AWSCompatibility().AccessKey().Create(
+	res, err := r.sdk.IAM().AWSCompatibility().AccessKey().Create(
 		ctx, &awscompatibility.CreateAccessKeyRequest{
 			ServiceAccountId: saID,
 			Description:      description,
 		},
 	)
+	return res, errorhandling.NewCloudError(err, "CreateAccessKey", saID)
 }
 
 func (r StaticAccessKeyAdapterSDK) Read(ctx context.Context, keyID string) (*awscompatibility.AccessKey, error) {
-	return r.sdk.IAM().AWSCompatibility().AccessKey().Get(
+	res, err := r.sdk.IAM().AWSCompatibility().AccessKey().Get(
 		ctx, &awscompatibility.GetAccessKeyRequest{
 			AccessKeyId: keyID,
 		},
 	)
+	return res, errorhandling.NewCloudError(err, "GetAccessKey", keyID)
 }
 
 func (r StaticAccessKeyAdapterSDK) Delete(ctx context.Context, sakeyID string) error {
@@ -45,20 +49,24 @@ func (r StaticAccessKeyAdapterSDK) Delete(ctx context.Context, sakeyID string) e
 			AccessKeyId: sakeyID,
 		},
 	); err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "DeleteAccessKey", sakeyID)
 	}
 
 	return nil
 }
 
 func (r StaticAccessKeyAdapterSDK) List(ctx context.Context, saID string) ([]*awscompatibility.AccessKey, error) {
-	list, err := r.sdk.IAM().AWSCompatibility().AccessKey().List(
+	var res []*awscompatibility.AccessKey
+	it := r.sdk.IAM().AWSCompatibility().AccessKey().AccessKeyIterator(
 		ctx, &awscompatibility.ListAccessKeysRequest{
 			ServiceAccountId: saID,
 		},
 	)
-	if err != nil {
-		return nil, err
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListAccessKeys", saID)
 	}
-	return list.AccessKeys, nil
+	return res, nil
 }
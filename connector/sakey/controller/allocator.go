@@ -10,21 +10,28 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 	"go.uber.org/multierr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
 	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *staticAccessKeyReconciler) allocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
-) (*awscompatibility.AccessKey, error) {
+) (_ *awscompatibility.AccessKey, err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	res, err := sakeyutils.GetStaticAccessKey(
-		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
+		ctx, object.Status.KeyID, sakeyutils.ServiceAccountID(object), r.clusterID, object.Name, r.adapter,
 	)
 	if err == nil {
 		return res, nil
@@ -32,20 +39,20 @@ func (r *staticAccessKeyReconciler) allocateResource(
 	if !errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotFound) {
 		return nil, fmt.Errorf("unable to get resource: %w", err)
 	}
+
+	if id, ok := object.Annotations[config.AdoptAnnotation]; ok && object.Status.KeyID == "" {
+		return r.adoptResource(ctx, log, object, id)
+	}
+
 	response, err := r.adapter.Create(
-		ctx, object.Spec.ServiceAccountID, sakeyconfig.GetStaticAccessKeyDescription(r.clusterID, object.Name),
+		ctx, sakeyutils.ServiceAccountID(object), sakeyconfig.GetStaticAccessKeyDescription(r.clusterID, object.Name),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create resource: %w", err)
 	}
 
 	// Now we need to create a secret with the key
-	if err := secret.Put(
-		ctx, r.Client, object.Name, object.Namespace, sakeyconfig.ShortName, map[string]string{
-			"key":    response.AccessKey.KeyId,
-			"secret": response.Secret,
-		},
-	); err != nil {
+	if err := r.putSecret(ctx, object, response.AccessKey.KeyId, response.Secret); err != nil {
 		// If we cannot create secret, we will just delete key
 		// and try again on the next reconciliation
 		err := fmt.Errorf("unable to create secret: %w", err)
@@ -65,20 +72,70 @@ func (r *staticAccessKeyReconciler) allocateResource(
 	return response.AccessKey, nil
 }
 
+// adoptResource takes over existing key. Cloud returns secret part of the key only once, on creation,
+// so secret of this object must already be created with both parts of the adopted key.
+func (r *staticAccessKeyReconciler) adoptResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey, keyID string,
+) (*awscompatibility.AccessKey, error) {
+	res, err := r.adapter.Read(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get adopted resource: %w", err)
+	}
+	if res.ServiceAccountId != sakeyutils.ServiceAccountID(object) {
+		return nil, fmt.Errorf(
+			"adopted resource belongs to service account %s instead of %s",
+			res.ServiceAccountId, sakeyutils.ServiceAccountID(object),
+		)
+	}
+
+	secretName := secret.Name(object.Name, sakeyconfig.ShortName)
+	var sec v1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: object.Namespace, Name: secretName}, &sec); err != nil {
+		return nil, fmt.Errorf("unable to get secret with adopted key: %w", err)
+	}
+	if string(sec.Data["key"]) != res.KeyId || len(sec.Data["secret"]) == 0 {
+		return nil, fmt.Errorf("secret %s must contain key %s and its secret", secretName, res.KeyId)
+	}
+
+	object.Status.SecretName = secretName
+	if err := r.Client.Update(ctx, object); err != nil {
+		return nil, fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("resource adopted", "id", res.Id)
+	return res, nil
+}
+
 func (r *staticAccessKeyReconciler) deallocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if err := secret.Remove(ctx, r.Client, object.Name, object.Namespace, sakeyconfig.ShortName); err != nil {
 		return fmt.Errorf("unable to delete secret: %w", err)
 	}
 
-	res, err := sakeyutils.GetStaticAccessKey(
-		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, r.clusterID, object.Name, r.adapter,
-	)
+	if object.Status.PreviousKeyID != "" {
+		if err := r.adapter.Delete(ctx, object.Status.PreviousKeyID); err != nil &&
+			!errorhandling.CheckRPCErrorNotFound(err) {
+			return fmt.Errorf("unable to delete previous key: %w", err)
+		}
+	}
+
+	saID := sakeyutils.ServiceAccountID(object)
+	if saID == "" {
+		log.Info("service account was never resolved, nothing to delete")
+		return nil
+	}
+
+	res, err := sakeyutils.GetStaticAccessKey(ctx, object.Status.KeyID, saID, r.clusterID, object.Name, r.adapter)
 	if err != nil {
-		if errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotFound) {
+		// Keys are deleted together with their service account
+		if errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotFound) ||
+			errorhandling.CheckRPCErrorNotFound(err) {
 			log.Info("already deleted")
 			return nil
 		}
@@ -12,6 +12,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
 )
 
 func TestAllocate(t *testing.T) {
@@ -103,6 +105,57 @@ func TestAllocate(t *testing.T) {
 	)
 }
 
+func TestAdopt(t *testing.T) {
+	t.Run(
+		"allocate of adopted key uses provided secret", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			existing, err := ad.Create(ctx, "sukhov", "created by hand")
+			require.NoError(t, err)
+			obj := createObject("sukhov", "obj", "default")
+			obj.Annotations = map[string]string{config.AdoptAnnotation: existing.AccessKey.Id}
+			require.NoError(t, cl.Create(ctx, &obj))
+			require.NoError(t, secret.Put(ctx, cl, "obj", "default", sakeyconfig.ShortName, map[string]string{
+				"key":    existing.AccessKey.KeyId,
+				"secret": existing.Secret,
+			}))
+
+			// Act
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Equal(t, existing.AccessKey.Id, res.Id)
+			assert.Equal(t, secret.Name("obj", sakeyconfig.ShortName), obj.Status.SecretName)
+		},
+	)
+
+	t.Run(
+		"adopted key without secret is an error", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			existing, err := ad.Create(ctx, "sukhov", "created by hand")
+			require.NoError(t, err)
+			obj := createObject("sukhov", "obj", "default")
+			obj.Annotations = map[string]string{config.AdoptAnnotation: existing.AccessKey.Id}
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			_, err = rc.allocateResource(ctx, log, &obj)
+			require.Error(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Empty(t, obj.Status.SecretName)
+		},
+	)
+}
+
 func TestDeallocate(t *testing.T) {
 	t.Run(
 		"deallocate on cloud with resource deletes resource", func(t *testing.T) {
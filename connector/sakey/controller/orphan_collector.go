@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller/adapter"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// orphanCollector finds access keys described as issued for this cluster that have
+// no StaticAccessKey object with the same name for the same service account.
+type orphanCollector struct {
+	cl              client.Reader
+	adapter         adapter.StaticAccessKeyAdapter
+	clusterID       string
+	serviceAccounts []string
+}
+
+// NewOrphanCollector creates collector that looks through keys of the given service
+// accounts and service accounts of all existing StaticAccessKey objects.
+func NewOrphanCollector(cl client.Reader, sdk *ycsdk.SDK, clusterID string, serviceAccounts []string) gc.Collector {
+	return &orphanCollector{
+		cl:              cl,
+		adapter:         adapter.NewStaticAccessKeyAdapter(sdk),
+		clusterID:       clusterID,
+		serviceAccounts: serviceAccounts,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return sakeyconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.StaticAccessKeyList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	serviceAccounts := append([]string{}, r.serviceAccounts...)
+	backed := map[string]bool{}
+	for i := range objects.Items {
+		object := &objects.Items[i]
+		saID := sakeyutils.ServiceAccountID(object)
+		if saID == "" {
+			continue
+		}
+		if !util.ContainsString(serviceAccounts, saID) {
+			serviceAccounts = append(serviceAccounts, saID)
+		}
+		backed[saID+"/"+object.Name] = true
+	}
+
+	var res []gc.Resource
+	for _, sa := range serviceAccounts {
+		lst, err := r.adapter.List(ctx, sa)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list keys of service account %s: %w", sa, err)
+		}
+		for _, key := range lst {
+			cluster, name, ok := sakeyconfig.ParseStaticAccessKeyDescription(key.Description)
+			if !ok || cluster != r.clusterID || backed[sa+"/"+name] {
+				continue
+			}
+			res = append(res, gc.Resource{ID: key.Id, Name: name, Location: sa})
+		}
+	}
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	return r.adapter.Delete(ctx, resource.ID)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+)
+
+func TestOrphans(t *testing.T) {
+	t.Run("key without object is orphaned", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		obj := createObject("sa", "obj", "default")
+		require.NoError(t, cl.Create(ctx, &obj))
+		_, err := rc.allocateResource(ctx, log, &obj)
+		require.NoError(t, err)
+		_, err = ad.Create(ctx, "sa", sakeyconfig.GetStaticAccessKeyDescription("test-cluster", "deleted"))
+		require.NoError(t, err)
+		_, err = ad.Create(ctx, "sa", sakeyconfig.GetStaticAccessKeyDescription("other-cluster", "deleted"))
+		require.NoError(t, err)
+		_, err = ad.Create(ctx, "sa", "created by hand")
+		require.NoError(t, err)
+		collector := &orphanCollector{cl: cl, adapter: ad, clusterID: "test-cluster"}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "deleted", orphans[0].Name)
+		assert.Equal(t, "sa", orphans[0].Location)
+	})
+
+	t.Run("orphan is deleted", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, ad, _ := setup(t)
+		_, err := ad.Create(ctx, "sa", sakeyconfig.GetStaticAccessKeyDescription("test-cluster", "deleted"))
+		require.NoError(t, err)
+		collector := &orphanCollector{cl: cl, adapter: ad, clusterID: "test-cluster", serviceAccounts: []string{"sa"}}
+		orphans, err := collector.Orphans(ctx)
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+
+		// Act
+		require.NoError(t, collector.Delete(ctx, orphans[0]))
+		lst, err := ad.List(ctx, "sa")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Empty(t, lst)
+	})
+}
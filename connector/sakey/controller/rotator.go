@@ -0,0 +1,181 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	"go.uber.org/multierr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// rotateKey reissues the key once it is older than rotation period. Replaced key
+// stays valid during the overlap and is deleted afterwards.
+func (r *staticAccessKeyReconciler) rotateKey(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey, res *awscompatibility.AccessKey,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "rotate-key")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if err := r.deletePreviousKey(ctx, log, object); err != nil {
+		return err
+	}
+
+	// Next rotation waits for the overlap of the previous one to end
+	if object.Spec.Rotation == nil || object.Status.PreviousKeyID != "" {
+		return nil
+	}
+	if r.now().Before(res.CreatedAt.AsTime().Add(object.Spec.Rotation.Period.Duration)) {
+		return nil
+	}
+
+	var sec v1.Secret
+	if err := r.Client.Get(
+		ctx, types.NamespacedName{Namespace: object.Namespace, Name: object.Status.SecretName}, &sec,
+	); err != nil {
+		return fmt.Errorf("unable to get secret: %w", err)
+	}
+
+	issued, err := r.findIssuedKey(ctx, log, object, res, string(sec.Data["key"]))
+	if err != nil {
+		return err
+	}
+	if issued == nil {
+		if issued, err = r.issueKey(ctx, object); err != nil {
+			return err
+		}
+	}
+
+	return r.completeRotation(ctx, log, object, res, issued)
+}
+
+// issueKey creates new key and replaces both of its parts in the secret with a single
+// write, so that users of the secret never see a mix of two keys.
+func (r *staticAccessKeyReconciler) issueKey(
+	ctx context.Context, object *connectorsv1.StaticAccessKey,
+) (*awscompatibility.AccessKey, error) {
+	response, err := r.adapter.Create(
+		ctx, sakeyutils.ServiceAccountID(object), sakeyconfig.GetStaticAccessKeyDescription(r.clusterID, object.Name),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create new key: %w", err)
+	}
+
+	if err := r.putSecret(ctx, object, response.AccessKey.KeyId, response.Secret); err != nil {
+		err := fmt.Errorf("unable to update secret: %w", err)
+		if err2 := r.adapter.Delete(ctx, response.AccessKey.Id); err2 != nil {
+			return nil, multierr.Append(err, fmt.Errorf("unable to delete new key in the cloud: %w", err2))
+		}
+		return nil, err
+	}
+	return response.AccessKey, nil
+}
+
+// completeRotation records in status that issued key has replaced the previous one in the secret.
+func (r *staticAccessKeyReconciler) completeRotation(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+	previous, issued *awscompatibility.AccessKey,
+) error {
+	var overlap time.Duration
+	if object.Spec.Rotation != nil {
+		overlap = object.Spec.Rotation.Overlap.Duration
+	}
+
+	rotatedAt := metav1.NewTime(r.now())
+	deleteAt := metav1.NewTime(rotatedAt.Add(overlap))
+	object.Status.KeyID = issued.Id
+	object.Status.PreviousKeyID = previous.Id
+	object.Status.PreviousKeyDeleteAt = &deleteAt
+	object.Status.RotationHistory = append(object.Status.RotationHistory, connectorsv1.StaticAccessKeyRotationRecord{
+		KeyID:         issued.Id,
+		PreviousKeyID: previous.Id,
+		RotatedAt:     rotatedAt,
+	})
+	if extra := len(object.Status.RotationHistory) - sakeyconfig.RotationHistoryLimit; extra > 0 {
+		object.Status.RotationHistory = object.Status.RotationHistory[extra:]
+	}
+	if err := r.Client.Update(ctx, object); err != nil {
+		return fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	r.recorder.Eventf(
+		object, v1.EventTypeNormal, sakeyconfig.ReasonKeyRotated,
+		"key %s is replaced with %s, it stays valid until %s",
+		previous.Id, issued.Id, deleteAt.UTC().Format(time.RFC3339),
+	)
+	log.Info("key rotated", "previous", previous.Id, "id", issued.Id)
+	return nil
+}
+
+// findIssuedKey looks for the key issued by interrupted rotation, which has updated the secret,
+// but has not recorded the key in status. Other keys left by interrupted rotations are deleted.
+func (r *staticAccessKeyReconciler) findIssuedKey(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+	current *awscompatibility.AccessKey, secretKeyID string,
+) (*awscompatibility.AccessKey, error) {
+	lst, err := r.adapter.List(ctx, sakeyutils.ServiceAccountID(object))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list keys: %w", err)
+	}
+
+	var issued *awscompatibility.AccessKey
+	for _, key := range lst {
+		if key.Id == current.Id || key.Id == object.Status.PreviousKeyID ||
+			key.Description != sakeyconfig.GetStaticAccessKeyDescription(r.clusterID, object.Name) {
+			continue
+		}
+		if key.KeyId == secretKeyID {
+			issued = key
+			continue
+		}
+		if err := r.adapter.Delete(ctx, key.Id); err != nil && !errorhandling.CheckRPCErrorNotFound(err) {
+			return nil, fmt.Errorf("unable to delete leftover key: %w", err)
+		}
+		log.Info("leftover key deleted", "id", key.Id)
+	}
+	return issued, nil
+}
+
+// deletePreviousKey deletes key replaced by the latest rotation once its overlap is over.
+func (r *staticAccessKeyReconciler) deletePreviousKey(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+) error {
+	id := object.Status.PreviousKeyID
+	if id == "" || object.Status.PreviousKeyDeleteAt != nil && r.now().Before(object.Status.PreviousKeyDeleteAt.Time) {
+		return nil
+	}
+
+	if err := r.adapter.Delete(ctx, id); err != nil && !errorhandling.CheckRPCErrorNotFound(err) {
+		return fmt.Errorf("unable to delete previous key: %w", err)
+	}
+
+	deletedAt := metav1.NewTime(r.now())
+	for i := range object.Status.RotationHistory {
+		if record := &object.Status.RotationHistory[i]; record.PreviousKeyID == id && record.PreviousKeyDeletedAt == nil {
+			record.PreviousKeyDeletedAt = &deletedAt
+		}
+	}
+	object.Status.PreviousKeyID = ""
+	object.Status.PreviousKeyDeleteAt = nil
+	if err := r.Client.Update(ctx, object); err != nil {
+		return fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("previous key deleted", "id", id)
+	return nil
+}
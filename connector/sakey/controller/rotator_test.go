@@ -0,0 +1,152 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+)
+
+func createRotatedObject(saID, metaName, namespace string) connectorsv1.StaticAccessKey {
+	obj := createObject(saID, metaName, namespace)
+	obj.Spec.Rotation = &connectorsv1.StaticAccessKeyRotation{
+		Period:  metav1.Duration{Duration: 90 * 24 * time.Hour},
+		Overlap: metav1.Duration{Duration: time.Hour},
+	}
+	return obj
+}
+
+func TestRotate(t *testing.T) {
+	t.Run(
+		"rotate on fresh key does nothing", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createRotatedObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+
+			// Act
+			require.NoError(t, rc.rotateKey(ctx, log, &obj, res))
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Equal(t, res.Id, obj.Status.KeyID)
+			assert.Empty(t, obj.Status.PreviousKeyID)
+			assert.Empty(t, obj.Status.RotationHistory)
+		},
+	)
+
+	t.Run(
+		"rotate on expired key issues new one and keeps previous", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createRotatedObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+			res.CreatedAt = timestamppb.New(time.Now().Add(-91 * 24 * time.Hour))
+			now := time.Now()
+			rc.now = func() time.Time { return now }
+
+			// Act
+			require.NoError(t, rc.rotateKey(ctx, log, &obj, res))
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+			var sec v1.Secret
+			require.NoError(t, cl.Get(ctx, client.ObjectKey{
+				Namespace: "default",
+				Name:      secret.Name("obj", sakeyconfig.ShortName),
+			}, &sec))
+
+			// Assert
+			assert.Len(t, lst, 2)
+			assert.NotEqual(t, res.Id, obj.Status.KeyID)
+			assert.Equal(t, obj.Status.KeyID, string(sec.Data["key"]))
+			assert.Equal(t, obj.Status.KeyID, string(sec.Data["secret"]))
+			assert.Equal(t, res.Id, obj.Status.PreviousKeyID)
+			assert.True(t, now.Add(time.Hour).Equal(obj.Status.PreviousKeyDeleteAt.Time))
+			require.Len(t, obj.Status.RotationHistory, 1)
+			assert.Equal(t, obj.Status.KeyID, obj.Status.RotationHistory[0].KeyID)
+			assert.Equal(t, res.Id, obj.Status.RotationHistory[0].PreviousKeyID)
+			assert.Nil(t, obj.Status.RotationHistory[0].PreviousKeyDeletedAt)
+		},
+	)
+
+	t.Run(
+		"rotate after overlap deletes previous key", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createRotatedObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+			res.CreatedAt = timestamppb.New(time.Now().Add(-91 * 24 * time.Hour))
+			now := time.Now()
+			rc.now = func() time.Time { return now }
+			require.NoError(t, rc.rotateKey(ctx, log, &obj, res))
+			current, err := ad.Read(ctx, obj.Status.KeyID)
+			require.NoError(t, err)
+			now = now.Add(2 * time.Hour)
+
+			// Act
+			require.NoError(t, rc.rotateKey(ctx, log, &obj, current))
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, lst, 1)
+			assert.Equal(t, current.Id, lst[0].Id)
+			assert.Empty(t, obj.Status.PreviousKeyID)
+			assert.Nil(t, obj.Status.PreviousKeyDeleteAt)
+			require.Len(t, obj.Status.RotationHistory, 1)
+			assert.NotNil(t, obj.Status.RotationHistory[0].PreviousKeyDeletedAt)
+		},
+	)
+
+	t.Run(
+		"rotate after interrupted rotation takes over key from secret", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createRotatedObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+			issued, err := ad.Create(ctx, "sukhov", sakeyconfig.GetStaticAccessKeyDescription("test-cluster", "obj"))
+			require.NoError(t, err)
+			require.NoError(t, secret.Put(ctx, cl, "obj", "default", sakeyconfig.ShortName, map[string]string{
+				"key":    issued.AccessKey.KeyId,
+				"secret": issued.Secret,
+			}))
+			res.CreatedAt = timestamppb.New(time.Now().Add(-91 * 24 * time.Hour))
+
+			// Act
+			require.NoError(t, rc.rotateKey(ctx, log, &obj, res))
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 2)
+			assert.Equal(t, issued.AccessKey.Id, obj.Status.KeyID)
+			assert.Equal(t, res.Id, obj.Status.PreviousKeyID)
+		},
+	)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// recoverSecret reissues the key if its secret was deleted or corrupted. Cloud returns secret
+// part of the key only on creation, so the only way to restore the secret is to replace the key.
+func (r *staticAccessKeyReconciler) recoverSecret(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey, res *awscompatibility.AccessKey,
+) (_ *awscompatibility.AccessKey, err error) {
+	ctx, span := tracing.Start(ctx, "recover-secret")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	secretName := secret.Name(object.Name, sakeyconfig.ShortName)
+	var sec v1.Secret
+	err = r.apiReader.Get(ctx, types.NamespacedName{Namespace: object.Namespace, Name: secretName}, &sec)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get secret: %w", err)
+	}
+	if err == nil && string(sec.Data["key"]) == res.KeyId && len(sec.Data["secret"]) != 0 {
+		return res, nil
+	}
+
+	// Secret holding another key of this object is left by rotation that was interrupted before
+	// it was recorded in status, such rotation is completed instead of issuing one more key
+	if err == nil && len(sec.Data["secret"]) != 0 {
+		issued, err := r.findIssuedKey(ctx, log, object, res, string(sec.Data["key"]))
+		if err != nil {
+			return nil, err
+		}
+		if issued != nil {
+			return issued, r.completeRotation(ctx, log, object, res, issued)
+		}
+	}
+
+	issued, err := r.issueKey(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+
+	// Old key is revoked before status is updated: if the update fails, the new key
+	// is still found by its description on the next reconciliation
+	if err := r.adapter.Delete(ctx, res.Id); err != nil && !errorhandling.CheckRPCErrorNotFound(err) {
+		return nil, fmt.Errorf("unable to revoke key: %w", err)
+	}
+
+	object.Status.KeyID = issued.Id
+	object.Status.SecretName = secretName
+	if err := r.Client.Update(ctx, object); err != nil {
+		return nil, fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	r.recorder.Eventf(
+		object, v1.EventTypeWarning, sakeyconfig.ReasonKeyReissued,
+		"secret %s is missing or corrupted, key %s is revoked and replaced with %s", secretName, res.Id, issued.Id,
+	)
+	log.Info("key reissued", "revoked", res.Id, "id", issued.Id)
+	return issued, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+)
+
+func TestRecoverSecret(t *testing.T) {
+	t.Run(
+		"recover on intact secret does nothing", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+
+			// Act
+			recovered, err := rc.recoverSecret(ctx, log, &obj, res)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, res, recovered)
+			assert.Len(t, lst, 1)
+			assert.Equal(t, res.Id, obj.Status.KeyID)
+		},
+	)
+
+	t.Run(
+		"recover on deleted secret reissues key", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+			require.NoError(t, secret.Remove(ctx, cl, "obj", "default", sakeyconfig.ShortName))
+
+			// Act
+			recovered, err := rc.recoverSecret(ctx, log, &obj, res)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+			var sec v1.Secret
+			require.NoError(t, cl.Get(ctx, client.ObjectKey{
+				Namespace: "default",
+				Name:      secret.Name("obj", sakeyconfig.ShortName),
+			}, &sec))
+
+			// Assert
+			require.Len(t, lst, 1)
+			assert.Equal(t, recovered.Id, lst[0].Id)
+			assert.NotEqual(t, res.Id, recovered.Id)
+			assert.Equal(t, recovered.Id, obj.Status.KeyID)
+			assert.Equal(t, recovered.KeyId, string(sec.Data["key"]))
+			assert.NotEmpty(t, sec.Data["secret"])
+			assert.Len(t, rc.recorder.(*record.FakeRecorder).Events, 1)
+		},
+	)
+
+	t.Run(
+		"recover on corrupted secret reissues key", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, res))
+			require.NoError(t, secret.Put(ctx, cl, "obj", "default", sakeyconfig.ShortName, map[string]string{
+				"key": res.KeyId,
+			}))
+
+			// Act
+			recovered, err := rc.recoverSecret(ctx, log, &obj, res)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, lst, 1)
+			assert.Equal(t, recovered.Id, lst[0].Id)
+			assert.NotEqual(t, res.Id, recovered.Id)
+			assert.Equal(t, recovered.Id, obj.Status.KeyID)
+		},
+	)
+
+	t.Run(
+		"recover on stale cached secret after rotation does nothing", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			previous, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, rc.updateStatus(ctx, log, &obj, previous))
+			var stale v1.Secret
+			require.NoError(t, cl.Get(ctx, client.ObjectKey{
+				Namespace: "default",
+				Name:      secret.Name("obj", sakeyconfig.ShortName),
+			}, &stale))
+			res, err := rc.issueKey(ctx, &obj)
+			require.NoError(t, err)
+			obj.Status.KeyID = res.Id
+			obj.Status.PreviousKeyID = previous.Id
+			require.NoError(t, cl.Update(ctx, &obj))
+			cache := k8sfake.NewFakeClient()
+			require.NoError(t, cache.Create(ctx, obj.DeepCopy()))
+			stale.ResourceVersion = ""
+			require.NoError(t, cache.Create(ctx, &stale))
+			rc.Client = cache
+
+			// Act
+			recovered, err := rc.recoverSecret(ctx, log, &obj, res)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, res, recovered)
+			assert.Len(t, lst, 2)
+			assert.Equal(t, res.Id, obj.Status.KeyID)
+			assert.Empty(t, rc.recorder.(*record.FakeRecorder).Events)
+		},
+	)
+}
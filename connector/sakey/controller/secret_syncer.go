@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// putSecret writes the key into the secret together with entries rendered by secret template of the object.
+func (r *staticAccessKeyReconciler) putSecret(
+	ctx context.Context, object *connectorsv1.StaticAccessKey, keyID, secretValue string,
+) error {
+	data, err := sakeyutils.RenderSecret(object.Spec.SecretTemplate, keyID, secretValue)
+	if err != nil {
+		return fmt.Errorf("unable to render secret: %w", err)
+	}
+	return secret.Put(ctx, r.Client, object.Name, object.Namespace, sakeyconfig.ShortName, data)
+}
+
+// syncSecret renders the secret again if its entries do not match secret template of the object.
+// Key is taken from the secret itself, because cloud does not return secret part of the key.
+func (r *staticAccessKeyReconciler) syncSecret(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "sync-secret")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	var sec v1.Secret
+	if err := r.Client.Get(
+		ctx, types.NamespacedName{Namespace: object.Namespace, Name: object.Status.SecretName}, &sec,
+	); err != nil {
+		return fmt.Errorf("unable to get secret: %w", err)
+	}
+
+	keyID, secretValue := string(sec.Data["key"]), string(sec.Data["secret"])
+	data, err := sakeyutils.RenderSecret(object.Spec.SecretTemplate, keyID, secretValue)
+	if err != nil {
+		return fmt.Errorf("unable to render secret: %w", err)
+	}
+	if secretMatches(&sec, data) {
+		return nil
+	}
+
+	if err := secret.Put(ctx, r.Client, object.Name, object.Namespace, sakeyconfig.ShortName, data); err != nil {
+		return fmt.Errorf("unable to update secret: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
+
+func secretMatches(sec *v1.Secret, data map[string]string) bool {
+	if len(sec.Data) != len(data) {
+		return false
+	}
+	for name, value := range data {
+		if actual, ok := sec.Data[name]; !ok || string(actual) != value {
+			return false
+		}
+	}
+	return true
+}
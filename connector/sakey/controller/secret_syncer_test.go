@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+)
+
+func TestSyncSecret(t *testing.T) {
+	t.Run(
+		"sync after template change renders secret with the same key", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, _, rc := setup(t)
+			obj := createObject("sukhov", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			obj.Spec.SecretTemplate = &connectorsv1.StaticAccessKeySecretTemplate{
+				Formats: []string{sakeyutils.FormatEnv},
+			}
+
+			// Act
+			require.NoError(t, rc.syncSecret(ctx, log, &obj))
+			var sec v1.Secret
+			require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: obj.Status.SecretName}, &sec))
+
+			// Assert
+			assert.Equal(t, res.KeyId, string(sec.Data["key"]))
+			assert.Equal(t, res.KeyId, string(sec.Data["AWS_ACCESS_KEY_ID"]))
+			assert.Equal(t, string(sec.Data["secret"]), string(sec.Data["AWS_SECRET_ACCESS_KEY"]))
+		},
+	)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	ysautils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// resolveServiceAccount writes id of YandexServiceAccount referenced by the object into its status.
+func (r *staticAccessKeyReconciler) resolveServiceAccount(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+) (err error) {
+	if object.Spec.ServiceAccountRef == nil {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "resolve-service-account")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	id, err := reference.Resolve(
+		ctx, r.Client, object.Namespace, object.Spec.ServiceAccountRef, ysautils.ReferenceKind,
+	)
+	if err := phase.SyncReferencesResolved(
+		ctx, log, object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return err
+	}
+	if object.Status.ServiceAccountID == id {
+		return nil
+	}
+
+	object.Status.ServiceAccountID = id
+	if err := r.Client.Update(ctx, object); err != nil {
+		return fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("successful", "id", id)
+	return nil
+}
+
+// keysOfServiceAccount maps YandexServiceAccount to keys referring to it, so
+// that they are issued as soon as the service account is ready.
+func (r *staticAccessKeyReconciler) keysOfServiceAccount(obj client.Object) []reconcile.Request {
+	var keys connectorsv1.StaticAccessKeyList
+	if err := r.Client.List(context.Background(), &keys); err != nil {
+		r.log.Error(err, "unable to list keys of service account", "name", obj.GetName())
+		return nil
+	}
+
+	var res []reconcile.Request
+	for _, key := range keys.Items {
+		if ref := key.Spec.ServiceAccountRef; ref != nil && ref.Matches(key.Namespace, ysautils.ReferenceKind.Name, obj) {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: key.Namespace, Name: key.Name},
+			})
+		}
+	}
+	return res
+}
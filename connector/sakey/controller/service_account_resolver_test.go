@@ -0,0 +1,134 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+)
+
+func createServiceAccount(name, namespace, id string, ready bool) ysa.YandexServiceAccount {
+	sa := ysa.YandexServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     ysa.YandexServiceAccountStatus{ID: id},
+	}
+	if ready {
+		meta.SetStatusCondition(&sa.Status.Conditions, metav1.Condition{
+			Type: phase.ConditionReady, Status: metav1.ConditionTrue, Reason: "Reconciled",
+		})
+	}
+	return sa
+}
+
+func serviceAccountRef(name, namespace string) *reference.ObjectReference {
+	return &reference.ObjectReference{Kind: "YandexServiceAccount", Name: name, Namespace: namespace}
+}
+
+func TestResolveServiceAccount(t *testing.T) {
+	t.Run(
+		"resolve writes id of ready service account into status", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			sa := createServiceAccount("sa", "accounts", "sukhov", true)
+			require.NoError(t, cl.Create(ctx, &sa))
+			obj := createObject("", "obj", "default")
+			obj.Spec.ServiceAccountRef = serviceAccountRef("sa", "accounts")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			require.NoError(t, rc.resolveServiceAccount(ctx, log, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "sukhov")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, "sukhov", obj.Status.ServiceAccountID)
+			assert.Equal(t, "sukhov", sakeyutils.ServiceAccountID(&obj))
+			assert.True(t, meta.IsStatusConditionTrue(obj.Status.Conditions, phase.ConditionReferencesResolved))
+			require.Len(t, lst, 1)
+			assert.Equal(t, res.Id, lst[0].Id)
+		},
+	)
+
+	t.Run(
+		"resolve fails until service account is ready", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, _, rc := setup(t)
+			sa := createServiceAccount("sa", "default", "sukhov", false)
+			require.NoError(t, cl.Create(ctx, &sa))
+			obj := createObject("", "obj", "default")
+			obj.Spec.ServiceAccountRef = serviceAccountRef("sa", "")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			err := rc.resolveServiceAccount(ctx, log, &obj)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Empty(t, obj.Status.ServiceAccountID)
+			condition := meta.FindStatusCondition(obj.Status.Conditions, phase.ConditionReferencesResolved)
+			require.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Contains(t, condition.Message, "is not ready")
+		},
+	)
+
+	t.Run(
+		"deallocate of never resolved key does nothing", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, _, rc := setup(t)
+			obj := createObject("", "obj", "default")
+			obj.Spec.ServiceAccountRef = serviceAccountRef("sa", "")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			err := rc.deallocateResource(ctx, log, &obj)
+
+			// Assert
+			assert.NoError(t, err)
+		},
+	)
+}
+
+func TestKeysOfServiceAccount(t *testing.T) {
+	t.Run(
+		"service account is mapped to keys referring to it from any namespace", func(t *testing.T) {
+			// Arrange
+			ctx, _, cl, _, rc := setup(t)
+			sa := createServiceAccount("sa", "accounts", "sukhov", true)
+			local := createObject("", "local", "accounts")
+			local.Spec.ServiceAccountRef = serviceAccountRef("sa", "")
+			remote := createObject("", "remote", "default")
+			remote.Spec.ServiceAccountRef = serviceAccountRef("sa", "accounts")
+			other := createObject("", "other", "default")
+			other.Spec.ServiceAccountRef = serviceAccountRef("sa", "")
+			plain := createObject("sukhov", "plain", "accounts")
+			for _, obj := range []*connectorsv1.StaticAccessKey{&local, &remote, &other, &plain} {
+				require.NoError(t, cl.Create(ctx, obj))
+			}
+
+			// Act
+			requests := rc.keysOfServiceAccount(&sa)
+
+			// Assert
+			require.Len(t, requests, 2)
+			assert.ElementsMatch(
+				t,
+				[]string{"accounts/local", "default/remote"},
+				[]string{requests[0].String(), requests[1].String()},
+			)
+		},
+	)
+}
@@ -6,43 +6,59 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	ycsdk "github.com/yandex-cloud/go-sdk"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller/adapter"
 	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 // staticAccessKeyReconciler reconciles a StaticAccessKey object
 type staticAccessKeyReconciler struct {
 	client.Client
+	// apiReader reads secrets bypassing the cache, which may still hold the secret of the key replaced by rotation
+	apiReader client.Reader
 	adapter   adapter.StaticAccessKeyAdapter
 	log       logr.Logger
 	clusterID string
+	recorder  record.EventRecorder
+	now       func() time.Time
 }
 
-func NewStaticAccessKeyReconciler(log logr.Logger, cl client.Client,
-	sdk *ycsdk.SDK, clusterID string) *staticAccessKeyReconciler {
+func NewStaticAccessKeyReconciler(log logr.Logger, cl client.Client, apiReader client.Reader,
+	sdk *ycsdk.SDK, clusterID string, recorder record.EventRecorder) *staticAccessKeyReconciler {
 	return &staticAccessKeyReconciler{
 		Client:    cl,
+		apiReader: apiReader,
 		adapter:   adapter.NewStaticAccessKeyAdapter(sdk),
 		log:       log,
 		clusterID: clusterID,
+		recorder:  recorder,
+		now:       time.Now,
 	}
 }
 
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexserviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *staticAccessKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("name", req.NamespacedName)
@@ -61,39 +77,87 @@ func (r *staticAccessKeyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
 	}
 
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Update)
+	if err != nil {
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
+	}
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
+	}
+
 	// If object must be currently finalized, do it and quit
 	if phase.MustBeFinalized(&object.ObjectMeta, sakeyconfig.FinalizerName) {
 		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
-			return config.GetErroredResult(fmt.Errorf("unable to finalize object: %w", err))
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
 		}
 		return config.GetNormalResult()
 	}
 
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *staticAccessKeyReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
+) error {
 	if err := phase.RegisterFinalizer(
-		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, &object, sakeyconfig.FinalizerName,
+		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, object, sakeyconfig.FinalizerName,
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to register finalizer: %w", err))
+		return fmt.Errorf("unable to register finalizer: %w", err)
 	}
 
-	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), &object)
+	if err := r.resolveServiceAccount(ctx, log.WithName("resolve-service-account"), object); err != nil {
+		return fmt.Errorf("unable to resolve service account: %w", err)
+	}
+
+	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), object)
 	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to allocate resource: %w", err))
+		return fmt.Errorf("unable to allocate resource: %w", err)
 	}
 
-	if err := r.updateStatus(ctx, log.WithName("update-status"), &object, res); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to update status: %w", err))
+	if err := r.updateStatus(ctx, log.WithName("update-status"), object, res); err != nil {
+		return fmt.Errorf("unable to update status: %w", err)
 	}
 
-	log.V(1).Info("finished reconciliation")
-	return config.GetNormalResult()
+	if res, err = r.recoverSecret(ctx, log.WithName("recover-secret"), object, res); err != nil {
+		return fmt.Errorf("unable to recover secret: %w", err)
+	}
+
+	if err := r.rotateKey(ctx, log.WithName("rotate-key"), object, res); err != nil {
+		return fmt.Errorf("unable to rotate key: %w", err)
+	}
+
+	if err := r.syncSecret(ctx, log.WithName("sync-secret"), object); err != nil {
+		return fmt.Errorf("unable to sync secret: %w", err)
+	}
+
+	return nil
 }
 
 func (r *staticAccessKeyReconciler) finalize(
 	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
-	if err := r.deallocateResource(ctx, log.WithName("deallocate-resource"), object); err != nil {
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object)
+	}); err != nil {
 		return fmt.Errorf("unable to deallocate resource: %w", err)
 	}
 
@@ -108,8 +172,13 @@ func (r *staticAccessKeyReconciler) finalize(
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *staticAccessKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *staticAccessKeyReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&connectorsv1.StaticAccessKey{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &ysa.YandexServiceAccount{}},
+			handler.EnqueueRequestsFromMapFunc(r.keysOfServiceAccount),
+		).
+		WithOptions(opts).
+		Complete(tracing.Reconciler(sakeyconfig.LongName, r))
 }
@@ -11,11 +11,15 @@ import (
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *staticAccessKeyReconciler) updateStatus(
 	ctx context.Context, log logr.Logger, object *connectorsv1.StaticAccessKey, res *awscompatibility.AccessKey,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "update-status")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 	// We must not forget that field SecretName is
 	// managed by another phase and therefore only
@@ -6,9 +6,11 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
@@ -29,10 +31,13 @@ func setup(t *testing.T) (
 	cl := k8sfake.NewFakeClient()
 	log := logrfake.NewFakeLogger(t)
 	return context.Background(), log, cl, &ad, staticAccessKeyReconciler{
+		cl,
 		cl,
 		&ad,
 		log,
 		"test-cluster",
+		record.NewFakeRecorder(10),
+		time.Now,
 	}
 }
 
@@ -3,16 +3,33 @@
 
 package config
 
-import "github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+import (
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
 
 const (
 	FinalizerName = "finalizer.sakey.connectors.cloud.yandex.com"
 	LongName      = "StaticAccessKey"
 	ShortName     = "sakey"
 
-	ErrCodeSAKeyNotFound = "yc.sakey.not-found"
+	ErrCodeSAKeyNotFound   = "yc.sakey.not-found"
+	ErrCodeSAKeyNotGranted = "yc.sakey.not-granted"
+
+	ReasonKeyRotated     = "KeyRotated"
+	ReasonKeyReissued    = "KeyReissued"
+	RotationHistoryLimit = 10
+
+	DefaultStorageEndpoint = "storage.yandexcloud.net"
+	DefaultRegion          = "ru-central1"
+	DefaultProfile         = "default"
 )
 
 func GetStaticAccessKeyDescription(clusterName, name string) string {
-	return config.CloudClusterLabel + ":" + clusterName + "\n" + config.CloudNameLabel + ":" + name
+	return config.GetCloudDescription(clusterName, name)
+}
+
+// ParseStaticAccessKeyDescription is the inverse of GetStaticAccessKeyDescription, it fails
+// on descriptions of keys that were not created by connectors.
+func ParseStaticAccessKeyDescription(description string) (clusterName, name string, ok bool) {
+	return config.ParseCloudDescription(description)
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// CheckGrant checks that object of kind in namespace may use the key. Key of the same
+// namespace may always be used, key of another namespace must be granted with StaticAccessKeyGrant.
+func CheckGrant(ctx context.Context, cl client.Reader, kind, namespace string, key types.NamespacedName) error {
+	if key.Namespace == namespace {
+		return nil
+	}
+
+	var grants connectorsv1.StaticAccessKeyGrantList
+	if err := cl.List(ctx, &grants, client.InNamespace(key.Namespace)); err != nil {
+		return fmt.Errorf("unable to list grants of static access keys: %w", err)
+	}
+	for i := range grants.Items {
+		if GrantAllows(&grants.Items[i], kind, namespace, key.Name) {
+			return nil
+		}
+	}
+
+	return errorhandling.New(
+		fmt.Sprintf("static access key %s is not granted to %s of namespace %s", key, kind, namespace),
+		sakeyconfig.ErrCodeSAKeyNotGranted,
+		nil,
+	)
+}
+
+// GrantAllows reports whether grant allows object of kind in namespace to use key with the name.
+func GrantAllows(grant *connectorsv1.StaticAccessKeyGrant, kind, namespace, name string) bool {
+	if len(grant.Spec.KeyNames) != 0 && !util.ContainsString(grant.Spec.KeyNames, name) {
+		return false
+	}
+	for _, from := range grant.Spec.From {
+		if from.Namespace == namespace && (from.Kind == "" || from.Kind == kind) {
+			return true
+		}
+	}
+	return false
+}
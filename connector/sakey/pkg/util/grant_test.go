@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+)
+
+func TestGrantAllows(t *testing.T) {
+	grant := &connectorsv1.StaticAccessKeyGrant{
+		Spec: connectorsv1.StaticAccessKeyGrantSpec{
+			KeyNames: []string{"shared"},
+			From: []connectorsv1.StaticAccessKeyGrantFrom{
+				{Namespace: "app", Kind: "YandexObjectStorage"},
+				{Namespace: "jobs"},
+			},
+		},
+	}
+
+	t.Run("grant allows listed kind of listed namespace", func(t *testing.T) {
+		assert.True(t, GrantAllows(grant, "YandexObjectStorage", "app", "shared"))
+	})
+
+	t.Run("grant without kind allows any kind", func(t *testing.T) {
+		assert.True(t, GrantAllows(grant, "YandexMessageQueue", "jobs", "shared"))
+	})
+
+	t.Run("grant does not allow other kinds, namespaces and keys", func(t *testing.T) {
+		assert.False(t, GrantAllows(grant, "YandexMessageQueue", "app", "shared"))
+		assert.False(t, GrantAllows(grant, "YandexObjectStorage", "other", "shared"))
+		assert.False(t, GrantAllows(grant, "YandexObjectStorage", "app", "private"))
+	})
+}
+
+func TestCheckGrant(t *testing.T) {
+	t.Run("key of the same namespace needs no grant", func(t *testing.T) {
+		// Arrange
+		cl := k8sfake.NewFakeClient()
+
+		// Act
+		err := CheckGrant(
+			context.Background(), cl, "YandexObjectStorage", "app", types.NamespacedName{Namespace: "app", Name: "key"},
+		)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("key of another namespace needs grant", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		key := types.NamespacedName{Namespace: "platform", Name: "key"}
+
+		// Act
+		before := CheckGrant(ctx, cl, "YandexObjectStorage", "app", key)
+		require.NoError(t, cl.Create(ctx, &connectorsv1.StaticAccessKeyGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "grant"},
+			Spec: connectorsv1.StaticAccessKeyGrantSpec{
+				From: []connectorsv1.StaticAccessKeyGrantFrom{{Namespace: "app"}},
+			},
+		}))
+		after := CheckGrant(ctx, cl, "YandexObjectStorage", "app", key)
+
+		// Assert
+		require.Error(t, before)
+		assert.True(t, errorhandling.CheckConnectorErrorCode(before, sakeyconfig.ErrCodeSAKeyNotGranted))
+		assert.NoError(t, after)
+	})
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+)
+
+// ReferenceKind resolves references to StaticAccessKey into id of its key.
+var ReferenceKind = reference.Kind{
+	Name: sakeyconfig.LongName,
+	New: func() client.Object {
+		return &connectorsv1.StaticAccessKey{}
+	},
+	Status: func(obj client.Object) (string, []metav1.Condition) {
+		casted := obj.(*connectorsv1.StaticAccessKey)
+		return casted.Status.KeyID, casted.Status.Conditions
+	},
+}
@@ -9,11 +9,21 @@ import (
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller/adapter"
 	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 )
 
+// ServiceAccountID returns id of service account of the key, it is empty
+// if the key refers to YandexServiceAccount that is not yet resolved.
+func ServiceAccountID(object *connectorsv1.StaticAccessKey) string {
+	if object.Spec.ServiceAccountID != "" {
+		return object.Spec.ServiceAccountID
+	}
+	return object.Status.ServiceAccountID
+}
+
 func GetStaticAccessKey(
 	ctx context.Context, keyID, saID, clusterName, name string, ad adapter.StaticAccessKeyAdapter,
 ) (*awscompatibility.AccessKey, error) {
@@ -34,7 +44,6 @@ func GetStaticAccessKey(
 
 	// We may have not yet written this key into status,
 	// But we can list objects and match by description
-	// TODO (covariance) pagination
 	lst, err := ad.List(ctx, saID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot list resources in cloud: %w", err)
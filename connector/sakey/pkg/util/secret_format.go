@@ -0,0 +1,143 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+)
+
+const (
+	FormatEnv            = "env"
+	FormatAWSCredentials = "aws-credentials"
+	FormatS3cmd          = "s3cmd"
+	FormatRclone         = "rclone"
+)
+
+// SecretTemplateData is available to user-defined templates of the secret.
+type SecretTemplateData struct {
+	KeyID    string
+	Secret   string
+	Endpoint string
+	Region   string
+	Profile  string
+}
+
+var formats = map[string]map[string]string{
+	FormatEnv: {
+		"AWS_ACCESS_KEY_ID":     "{{ .KeyID }}",
+		"AWS_SECRET_ACCESS_KEY": "{{ .Secret }}",
+		"AWS_DEFAULT_REGION":    "{{ .Region }}",
+		"AWS_REGION":            "{{ .Region }}",
+		"AWS_ENDPOINT_URL":      "https://{{ .Endpoint }}",
+	},
+	FormatAWSCredentials: {
+		"credentials": `[{{ .Profile }}]
+aws_access_key_id = {{ .KeyID }}
+aws_secret_access_key = {{ .Secret }}
+`,
+		"config": `[{{ if ne .Profile "default" }}profile {{ end }}{{ .Profile }}]
+region = {{ .Region }}
+endpoint_url = https://{{ .Endpoint }}
+`,
+	},
+	FormatS3cmd: {
+		".s3cfg": `[default]
+access_key = {{ .KeyID }}
+secret_key = {{ .Secret }}
+bucket_location = {{ .Region }}
+host_base = {{ .Endpoint }}
+host_bucket = %(bucket)s.{{ .Endpoint }}
+`,
+	},
+	FormatRclone: {
+		"rclone.conf": `[{{ .Profile }}]
+type = s3
+provider = Other
+env_auth = false
+access_key_id = {{ .KeyID }}
+secret_access_key = {{ .Secret }}
+region = {{ .Region }}
+endpoint = https://{{ .Endpoint }}
+`,
+	},
+}
+
+// SecretFormats returns names of predefined formats of the secret.
+func SecretFormats() []string {
+	res := make([]string, 0, len(formats))
+	for name := range formats {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// RenderSecret returns entries of the secret holding given key. Besides "key" and "secret", which are
+// always present, it contains entries of the formats and user-defined entries of the template.
+func RenderSecret(tmpl *connectorsv1.StaticAccessKeySecretTemplate, keyID, secret string) (map[string]string, error) {
+	res := map[string]string{}
+	if tmpl != nil {
+		data := SecretTemplateData{
+			KeyID:    keyID,
+			Secret:   secret,
+			Endpoint: withDefault(tmpl.Endpoint, sakeyconfig.DefaultStorageEndpoint),
+			Region:   withDefault(tmpl.Region, sakeyconfig.DefaultRegion),
+			Profile:  withDefault(tmpl.Profile, sakeyconfig.DefaultProfile),
+		}
+		for _, format := range tmpl.Formats {
+			entries, ok := formats[format]
+			if !ok {
+				return nil, fmt.Errorf("unknown secret format %s, must be one of %s", format, strings.Join(SecretFormats(), ", "))
+			}
+			for name, text := range entries {
+				if err := render(res, name, text, data); err != nil {
+					return nil, err
+				}
+			}
+		}
+		// User-defined entries override entries of the formats
+		for name, text := range tmpl.Data {
+			if err := render(res, name, text, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res["key"] = keyID
+	res["secret"] = secret
+	return res, nil
+}
+
+// ParseSecretTemplate checks that user-defined entries of the template are valid templates.
+func ParseSecretTemplate(tmpl *connectorsv1.StaticAccessKeySecretTemplate) error {
+	_, err := RenderSecret(tmpl, "key", "secret")
+	return err
+}
+
+func render(res map[string]string, name, text string, data SecretTemplateData) error {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("unable to parse template of entry %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("unable to render entry %s: %w", name, err)
+	}
+	res[name] = buf.String()
+	return nil
+}
+
+func withDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+)
+
+func TestRenderSecret(t *testing.T) {
+	t.Run("no template renders key and secret only", func(t *testing.T) {
+		// Act
+		data, err := RenderSecret(nil, "id", "value")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, map[string]string{"key": "id", "secret": "value"}, data)
+	})
+
+	t.Run("formats render entries with defaults", func(t *testing.T) {
+		// Arrange
+		tmpl := &connectorsv1.StaticAccessKeySecretTemplate{Formats: []string{FormatEnv, FormatAWSCredentials}}
+
+		// Act
+		data, err := RenderSecret(tmpl, "id", "value")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "id", data["key"])
+		assert.Equal(t, "id", data["AWS_ACCESS_KEY_ID"])
+		assert.Equal(t, "value", data["AWS_SECRET_ACCESS_KEY"])
+		assert.Equal(t, "ru-central1", data["AWS_DEFAULT_REGION"])
+		assert.Equal(t, "https://storage.yandexcloud.net", data["AWS_ENDPOINT_URL"])
+		assert.Equal(t, "[default]\naws_access_key_id = id\naws_secret_access_key = value\n", data["credentials"])
+		assert.Equal(t, "[default]\nregion = ru-central1\nendpoint_url = https://storage.yandexcloud.net\n", data["config"])
+	})
+
+	t.Run("user-defined entries override formats", func(t *testing.T) {
+		// Arrange
+		tmpl := &connectorsv1.StaticAccessKeySecretTemplate{
+			Formats: []string{FormatEnv},
+			Region:  "il1",
+			Data: map[string]string{
+				"AWS_REGION": "custom",
+				"dsn":        "s3://{{ .KeyID }}:{{ .Secret }}@{{ .Endpoint }}/{{ .Region }}",
+			},
+		}
+
+		// Act
+		data, err := RenderSecret(tmpl, "id", "value")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "custom", data["AWS_REGION"])
+		assert.Equal(t, "il1", data["AWS_DEFAULT_REGION"])
+		assert.Equal(t, "s3://id:value@storage.yandexcloud.net/il1", data["dsn"])
+	})
+
+	t.Run("unknown format and field fail", func(t *testing.T) {
+		// Act
+		_, err1 := RenderSecret(&connectorsv1.StaticAccessKeySecretTemplate{Formats: []string{"boto"}}, "id", "value")
+		_, err2 := RenderSecret(
+			&connectorsv1.StaticAccessKeySecretTemplate{Data: map[string]string{"x": "{{ .Token }}"}}, "id", "value",
+		)
+
+		// Assert
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+	})
+}
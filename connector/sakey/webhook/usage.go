@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// usersOfKey returns objects of all namespaces that use the key, either with its name or with reference to it.
+// Only kinds of enabled connectors are looked through, kinds that are not installed have no users. Objects
+// being deleted are not users, as they are able to clean up with credentials cached by their connectors.
+func usersOfKey(ctx context.Context, cl client.Reader, key types.NamespacedName, enabled []string) ([]string, error) {
+	var res []string
+
+	if util.ContainsString(enabled, ymqconfig.ShortName) {
+		var queues ymq.YandexMessageQueueList
+		if err := cl.List(ctx, &queues); err != nil && !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("unable to list message queues: %w", err)
+		}
+		for i := range queues.Items {
+			queue := &queues.Items[i]
+			if queue.DeletionTimestamp == nil && awsutils.StaticAccessKeyName(
+				queue.Namespace, queue.Spec.SAKeyNamespace, queue.Spec.SAKeyName, queue.Spec.SAKeyRef,
+			) == key {
+				res = append(res, fmt.Sprintf("%s %s/%s", ymqconfig.LongName, queue.Namespace, queue.Name))
+			}
+		}
+	}
+
+	if util.ContainsString(enabled, yosconfig.ShortName) {
+		var buckets yos.YandexObjectStorageList
+		if err := cl.List(ctx, &buckets); err != nil && !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("unable to list buckets: %w", err)
+		}
+		for i := range buckets.Items {
+			bucket := &buckets.Items[i]
+			if bucket.DeletionTimestamp == nil && awsutils.StaticAccessKeyName(
+				bucket.Namespace, bucket.Spec.SAKeyNamespace, bucket.Spec.SAKeyName, bucket.Spec.SAKeyRef,
+			) == key {
+				res = append(res, fmt.Sprintf("%s %s/%s", yosconfig.LongName, bucket.Namespace, bucket.Name))
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -6,6 +6,8 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 
@@ -13,8 +15,13 @@ import (
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
 )
@@ -22,11 +29,14 @@ import (
 // +kubebuilder:webhook:path=/validate-connectors-cloud-yandex-com-v1-staticaccesskey,mutating=false,failurePolicy=fail,sideEffects=None,groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=create;update;delete,versions=v1,name=vstaticaccesskey.yandex.com,admissionReviewVersions=v1
 
 type SAKeyValidator struct {
+	cl  client.Client
 	sdk *ycsdk.SDK
+	// enabled are short names of enabled connectors, keys are checked for users of their kinds only
+	enabled []string
 }
 
-func NewSAKeyValidator(sdk *ycsdk.SDK) webhook.Validator {
-	return &SAKeyValidator{sdk: sdk}
+func NewSAKeyValidator(cl client.Client, sdk *ycsdk.SDK, enabled []string) webhook.Validator {
+	return &SAKeyValidator{cl: cl, sdk: sdk, enabled: enabled}
 }
 
 func (r *SAKeyValidator) ValidateCreation(ctx context.Context, log logr.Logger, obj runtime.Object) error {
@@ -34,6 +44,24 @@ func (r *SAKeyValidator) ValidateCreation(ctx context.Context, log logr.Logger,
 
 	log.Info("validate create", "name", util.NamespacedName(casted))
 
+	if err := validateRotation(casted.Spec.Rotation); err != nil {
+		return err
+	}
+	if err := validateSecretTemplate(casted.Spec.SecretTemplate); err != nil {
+		return err
+	}
+
+	if (casted.Spec.ServiceAccountID == "") == (casted.Spec.ServiceAccountRef == nil) {
+		return webhook.NewValidationErrorf("exactly one of serviceAccountId and serviceAccountRef must be set")
+	}
+	// Referenced YandexServiceAccount may be created later, key waits for it
+	if casted.Spec.ServiceAccountRef != nil {
+		if err := casted.Spec.ServiceAccountRef.Validate(ysaconfig.LongName); err != nil {
+			return webhook.NewValidationError(err)
+		}
+		return nil
+	}
+
 	if _, err := r.sdk.IAM().ServiceAccount().Get(
 		ctx, &iam.GetServiceAccountRequest{
 			ServiceAccountId: casted.Spec.ServiceAccountID,
@@ -63,11 +91,73 @@ func (r *SAKeyValidator) ValidateUpdate(_ context.Context, log logr.Logger, curr
 			castedCurrent.Spec.ServiceAccountID,
 		)
 	}
+	if !reflect.DeepEqual(castedCurrent.Spec.ServiceAccountRef, castedOld.Spec.ServiceAccountRef) {
+		return webhook.NewValidationErrorf("bound service account reference must be immutable")
+	}
+
+	if err := validateRotation(castedCurrent.Spec.Rotation); err != nil {
+		return err
+	}
+	return validateSecretTemplate(castedCurrent.Spec.SecretTemplate)
+}
 
+func validateRotation(rotation *v1.StaticAccessKeyRotation) error {
+	if rotation == nil {
+		return nil
+	}
+	if rotation.Period.Duration <= 0 {
+		return webhook.NewValidationErrorf("rotation period must be positive, got %s", rotation.Period.Duration)
+	}
+	if rotation.Overlap.Duration < 0 || rotation.Overlap.Duration >= rotation.Period.Duration {
+		return webhook.NewValidationErrorf(
+			"rotation overlap must be non-negative and less than period %s, got %s",
+			rotation.Period.Duration, rotation.Overlap.Duration,
+		)
+	}
 	return nil
 }
 
-func (r *SAKeyValidator) ValidateDeletion(_ context.Context, log logr.Logger, obj runtime.Object) error {
-	log.Info("validate delete", "name", util.NamespacedName(obj.(*v1.StaticAccessKey)))
+func (r *SAKeyValidator) ValidateDeletion(ctx context.Context, log logr.Logger, obj runtime.Object) error {
+	casted := obj.(*v1.StaticAccessKey)
+
+	log.Info("validate delete", "name", util.NamespacedName(casted))
+
+	// Users of orphaned or force-deleted key are left to clean up with credentials cached by their connectors
+	if phase.CleanupBypassed(casted) {
+		return nil
+	}
+
+	users, err := usersOfKey(ctx, r.cl, util.NamespacedName(casted), r.enabled)
+	if err != nil {
+		return err
+	}
+	if len(users) != 0 {
+		return webhook.NewValidationErrorf(
+			"static access key is still used by %s, delete them or switch them to another key first",
+			strings.Join(users, ", "),
+		)
+	}
+
+	return nil
+}
+
+func validateSecretTemplate(tmpl *v1.StaticAccessKeySecretTemplate) error {
+	if tmpl == nil {
+		return nil
+	}
+	if strings.Contains(tmpl.Endpoint, "://") {
+		return webhook.NewValidationErrorf("secret template endpoint must be a host without scheme: %s", tmpl.Endpoint)
+	}
+	for name := range tmpl.Data {
+		if name == "key" || name == "secret" {
+			return webhook.NewValidationErrorf("secret template must not override entry %s", name)
+		}
+		if errs := validation.IsConfigMapKey(name); len(errs) != 0 {
+			return webhook.NewValidationErrorf("invalid secret template entry %s: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	if err := sakeyutils.ParseSecretTemplate(tmpl); err != nil {
+		return webhook.NewValidationErrorf("invalid secret template: %v", err)
+	}
 	return nil
 }
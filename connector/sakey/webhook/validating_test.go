@@ -7,22 +7,104 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
 	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
 )
 
 func setupValidation(t *testing.T) (context.Context, webhook.Validator, logr.Logger) {
 	t.Helper()
-	return context.TODO(), &SAKeyValidator{}, logrfake.NewFakeLogger(t)
+	return context.TODO(),
+		&SAKeyValidator{cl: k8sfake.NewFakeClient(), enabled: []string{ymqconfig.ShortName, yosconfig.ShortName}},
+		logrfake.NewFakeLogger(t)
 }
 
 func TestCreateValidation(t *testing.T) {
 	// TODO: test with ycsdk mock
+	t.Run("zero-period-is-invalid-creation", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		obj := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountID: "sukhov",
+				Rotation:         &v1.StaticAccessKeyRotation{},
+			},
+		}
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	t.Run("both-service-account-id-and-ref-is-invalid-creation", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		obj := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountID:  "sukhov",
+				ServiceAccountRef: &reference.ObjectReference{Kind: "YandexServiceAccount", Name: "sukhov"},
+			},
+		}
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	t.Run("service-account-ref-is-valid-creation", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		obj := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountRef: &reference.ObjectReference{
+					Kind: "YandexServiceAccount", Name: "sukhov", Namespace: "other",
+				},
+			},
+		}
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("service-account-ref-of-other-kind-is-invalid-creation", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		obj := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountRef: &reference.ObjectReference{Kind: "StaticAccessKey", Name: "sukhov"},
+			},
+		}
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
 }
 
 func TestUpdateValidation(t *testing.T) {
@@ -60,4 +142,197 @@ func TestUpdateValidation(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
 	})
+	t.Run("valid-rotation-is-valid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		current := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountID: "sukhov",
+				Rotation: &v1.StaticAccessKeyRotation{
+					Period:  metav1.Duration{Duration: 90 * 24 * time.Hour},
+					Overlap: metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("overlap-not-less-than-period-is-invalid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		current := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountID: "sukhov",
+				Rotation: &v1.StaticAccessKeyRotation{
+					Period:  metav1.Duration{Duration: time.Hour},
+					Overlap: metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+	t.Run("secret-template-overriding-key-is-invalid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		current := v1.StaticAccessKey{
+			Spec: v1.StaticAccessKeySpec{
+				ServiceAccountID: "sukhov",
+				SecretTemplate: &v1.StaticAccessKeySecretTemplate{
+					Data: map[string]string{"key": "{{ .KeyID }}"},
+				},
+			},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+}
+
+func TestDeleteValidation(t *testing.T) {
+	t.Run("unused-key-is-valid-deletion", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		obj := v1.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey"},
+			Spec:       v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("used-key-is-invalid-deletion", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		cl := wh.(*SAKeyValidator).cl
+		obj := v1.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey"},
+			Spec:       v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		require.NoError(t, cl.Create(ctx, &ymq.YandexMessageQueue{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "queue"},
+			Spec:       ymq.YandexMessageQueueSpec{Name: "queue", SAKeyName: "sakey"},
+		}))
+		require.NoError(t, cl.Create(ctx, &yos.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "bucket"},
+			Spec: yos.YandexObjectStorageSpec{
+				Name:     "bucket",
+				SAKeyRef: &reference.ObjectReference{Kind: "StaticAccessKey", Name: "sakey", Namespace: "default"},
+			},
+		}))
+		require.NoError(t, cl.Create(ctx, &yos.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "other-bucket"},
+			Spec:       yos.YandexObjectStorageSpec{Name: "other-bucket", SAKeyName: "sakey"},
+		}))
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+		assert.Contains(t, err.Error(), "YandexMessageQueue default/queue")
+		assert.Contains(t, err.Error(), "YandexObjectStorage app/bucket")
+		assert.NotContains(t, err.Error(), "other-bucket")
+	})
+
+	t.Run("key-used-by-disabled-connector-is-valid-deletion", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		wh.(*SAKeyValidator).enabled = []string{yosconfig.ShortName}
+		cl := wh.(*SAKeyValidator).cl
+		obj := v1.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey"},
+			Spec:       v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		require.NoError(t, cl.Create(ctx, &ymq.YandexMessageQueue{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "queue"},
+			Spec:       ymq.YandexMessageQueueSpec{Name: "queue", SAKeyName: "sakey"},
+		}))
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("key-used-by-deleted-objects-is-valid-deletion", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		cl := wh.(*SAKeyValidator).cl
+		now := metav1.Now()
+		obj := v1.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey", DeletionTimestamp: &now},
+			Spec:       v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+		}
+		require.NoError(t, cl.Create(ctx, &ymq.YandexMessageQueue{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "queue", DeletionTimestamp: &now},
+			Spec:       ymq.YandexMessageQueueSpec{Name: "queue", SAKeyName: "sakey"},
+		}))
+		require.NoError(t, cl.Create(ctx, &yos.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bucket", DeletionTimestamp: &now},
+			Spec:       yos.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+		}))
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	for _, annotation := range []string{config.OrphanAnnotation, config.ForceDeleteAnnotation} {
+		annotation := annotation
+		t.Run("used-key-with-"+annotation+"-is-valid-deletion", func(t *testing.T) {
+			// Arrange
+			ctx, wh, log := setupValidation(t)
+			cl := wh.(*SAKeyValidator).cl
+			obj := v1.StaticAccessKey{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "sakey",
+					Annotations: map[string]string{annotation: "true"},
+				},
+				Spec: v1.StaticAccessKeySpec{ServiceAccountID: "sukhov"},
+			}
+			require.NoError(t, cl.Create(ctx, &ymq.YandexMessageQueue{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "queue"},
+				Spec:       ymq.YandexMessageQueueSpec{Name: "queue", SAKeyName: "sakey"},
+			}))
+
+			// Act
+			err := wh.ValidateDeletion(ctx, log, &obj)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
 }
@@ -24,6 +24,7 @@ type YandexContainerRegistrySpec struct {
 	Name string `json:"name"`
 
 	// FolderID: id of a folder in which registry is located. Must be immutable.
+	// Folders are not managed by any connector, so there is no object to refer to instead of the id.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:
 	FolderID string `json:"folderId"`
@@ -46,6 +47,12 @@ type YandexContainerRegistryStatus struct {
 
 	// Labels: registry labels in key:value form. Maximum of 64 labels for resource is allowed
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // YandexContainerRegistry is the Schema for the yandexcontainerregistries API
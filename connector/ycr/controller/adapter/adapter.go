@@ -8,6 +8,8 @@ import (
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 )
 
 type YandexContainerRegistryAdapterSDK struct {
@@ -26,16 +28,16 @@ func (r YandexContainerRegistryAdapterSDK) Create(
 	op, err := r.sdk.WrapOperation(r.sdk.ContainerRegistry().Registry().Create(ctx, request))
 
 	if err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "CreateRegistry", request.Name)
 	}
 
 	if err := op.Wait(ctx); err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "CreateRegistry", request.Name)
 	}
 
 	res, err := op.Response()
 	if err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "CreateRegistry", request.Name)
 	}
 
 	return res.(*containerregistry.Registry), nil
@@ -44,25 +46,30 @@ func (r YandexContainerRegistryAdapterSDK) Create(
 func (r YandexContainerRegistryAdapterSDK) Read(ctx context.Context, registryID string) (
 	*containerregistry.Registry, error,
 ) {
-	return r.sdk.ContainerRegistry().Registry().Get(
+	res, err := r.sdk.ContainerRegistry().Registry().Get(
 		ctx, &containerregistry.GetRegistryRequest{
 			RegistryId: registryID,
 		},
 	)
+	return res, errorhandling.NewCloudError(err, "GetRegistry", registryID)
 }
 
 func (r YandexContainerRegistryAdapterSDK) List(ctx context.Context, folderID string) (
 	[]*containerregistry.Registry, error,
 ) {
-	list, err := r.sdk.ContainerRegistry().Registry().List(
+	var res []*containerregistry.Registry
+	it := r.sdk.ContainerRegistry().Registry().RegistryIterator(
 		ctx, &containerregistry.ListRegistriesRequest{
 			FolderId: folderID,
 		},
 	)
-	if err != nil {
-		return nil, err
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListRegistries", folderID)
 	}
-	return list.Registries, nil
+	return res, nil
 }
 
 func (r YandexContainerRegistryAdapterSDK) Update(
@@ -70,13 +77,13 @@ func (r YandexContainerRegistryAdapterSDK) Update(
 ) error {
 	op, err := r.sdk.WrapOperation(r.sdk.ContainerRegistry().Registry().Update(ctx, request))
 	if err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "UpdateRegistry", request.RegistryId)
 	}
 	if err := op.Wait(ctx); err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "UpdateRegistry", request.RegistryId)
 	}
 	if _, err := op.Response(); err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "UpdateRegistry", request.RegistryId)
 	}
 
 	return nil
@@ -91,10 +98,10 @@ func (r YandexContainerRegistryAdapterSDK) Delete(ctx context.Context, registryI
 		),
 	)
 	if err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "DeleteRegistry", registryID)
 	}
 	if err := op.Wait(ctx); err != nil {
-		return err
+		return errorhandling.NewCloudError(err, "DeleteRegistry", registryID)
 	}
 	return nil
 }
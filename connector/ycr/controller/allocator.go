@@ -9,17 +9,22 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
 	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
 	ycrutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/util"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *yandexContainerRegistryReconciler) allocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry,
-) (*containerregistry.Registry, error) {
+) (_ *containerregistry.Registry, err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	res, err := ycrutils.GetRegistry(
@@ -32,6 +37,10 @@ func (r *yandexContainerRegistryReconciler) allocateResource(
 		return nil, fmt.Errorf("unable to get resource: %w", err)
 	}
 
+	if id, ok := object.Annotations[config.AdoptAnnotation]; ok && object.Status.ID == "" {
+		return r.adoptResource(ctx, log, object, id)
+	}
+
 	resp, err := r.adapter.Create(
 		ctx, &containerregistry.CreateRegistryRequest{
 			FolderId: object.Spec.FolderID,
@@ -49,9 +58,52 @@ func (r *yandexContainerRegistryReconciler) allocateResource(
 	return resp, nil
 }
 
+// adoptResource takes over existing registry by labeling it the same way created registries are labeled.
+func (r *yandexContainerRegistryReconciler) adoptResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry, registryID string,
+) (*containerregistry.Registry, error) {
+	res, err := r.adapter.Read(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get adopted resource: %w", err)
+	}
+	if res.FolderId != object.Spec.FolderID {
+		return nil, fmt.Errorf("adopted resource is in folder %s instead of %s", res.FolderId, object.Spec.FolderID)
+	}
+	// Registry labeled by this cluster would have been found by the labels,
+	// so any cluster label here means that the registry is managed by someone else
+	if cluster, ok := res.Labels[config.CloudClusterLabel]; ok {
+		return nil, fmt.Errorf(
+			"adopted resource is already managed by %s in cluster %s", res.Labels[config.CloudNameLabel], cluster,
+		)
+	}
+
+	labels := map[string]string{}
+	for k, v := range res.Labels {
+		labels[k] = v
+	}
+	labels[config.CloudClusterLabel] = r.clusterID
+	labels[config.CloudNameLabel] = object.Name
+	if err := r.adapter.Update(
+		ctx, &containerregistry.UpdateRegistryRequest{
+			RegistryId: res.Id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"labels"}},
+			Labels:     labels,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("unable to label adopted resource: %w", err)
+	}
+	res.Labels = labels
+
+	log.Info("resource adopted", "id", res.Id)
+	return res, nil
+}
+
 func (r *yandexContainerRegistryReconciler) deallocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	ycr, err := ycrutils.GetRegistry(
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
 
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 )
@@ -64,6 +65,51 @@ func TestAllocate(t *testing.T) {
 	)
 }
 
+func TestAdopt(t *testing.T) {
+	t.Run(
+		"allocate of adopted resource labels it instead of creating", func(t *testing.T) {
+			// Arrange
+			ctx, log, _, ad, rc := setup(t)
+			existing, err := ad.Create(ctx, &containerregistry.CreateRegistryRequest{
+				FolderId: "folder", Name: "registry", Labels: map[string]string{"team": "infra"},
+			})
+			require.NoError(t, err)
+			obj := createObject("registry", "folder", "obj", "default")
+			obj.Annotations = map[string]string{config.AdoptAnnotation: existing.Id}
+
+			// Act
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "folder")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Equal(t, existing.Id, res.Id)
+			assert.Equal(t, "infra", lst[0].Labels["team"])
+			assert.Equal(t, "test-cluster", lst[0].Labels[config.CloudClusterLabel])
+			assert.Equal(t, "obj", lst[0].Labels[config.CloudNameLabel])
+		},
+	)
+
+	t.Run(
+		"resource managed by other cluster is not adopted", func(t *testing.T) {
+			// Arrange
+			ctx, log, _, ad, rc := setup(t)
+			existing := createResourceRequireNoError(ctx, t, ad, "registry", "folder", "obj", "other-cluster")
+			obj := createObject("registry", "folder", "obj", "default")
+			obj.Annotations = map[string]string{config.AdoptAnnotation: existing.Id}
+
+			// Act
+			_, err := rc.allocateResource(ctx, log, &obj)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Equal(t, "other-cluster", existing.Labels[config.CloudClusterLabel])
+		},
+	)
+}
+
 func TestDeallocate(t *testing.T) {
 	t.Run(
 		"deallocate on cloud with resource deletes resource", func(t *testing.T) {
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller/adapter"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// orphanCollector finds registries labeled with this cluster id that have no
+// YandexContainerRegistry object with the same name in the same folder.
+type orphanCollector struct {
+	cl        client.Reader
+	adapter   adapter.YandexContainerRegistryAdapter
+	clusterID string
+	folders   []string
+}
+
+// NewOrphanCollector creates collector that looks through the given folders and
+// folders of all existing YandexContainerRegistry objects.
+func NewOrphanCollector(cl client.Reader, sdk *ycsdk.SDK, clusterID string, folders []string) gc.Collector {
+	return &orphanCollector{
+		cl:        cl,
+		adapter:   adapter.NewYandexContainerRegistryAdapterSDK(sdk),
+		clusterID: clusterID,
+		folders:   folders,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return ycrconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.YandexContainerRegistryList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	folders := append([]string{}, r.folders...)
+	backed := map[string]bool{}
+	for _, object := range objects.Items {
+		if !util.ContainsString(folders, object.Spec.FolderID) {
+			folders = append(folders, object.Spec.FolderID)
+		}
+		backed[object.Spec.FolderID+"/"+object.Name] = true
+	}
+
+	var res []gc.Resource
+	for _, folder := range folders {
+		lst, err := r.adapter.List(ctx, folder)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list registries in folder %s: %w", folder, err)
+		}
+		for _, registry := range lst {
+			name, ok := registry.Labels[config.CloudNameLabel]
+			if !ok || registry.Labels[config.CloudClusterLabel] != r.clusterID || backed[folder+"/"+name] {
+				continue
+			}
+			res = append(res, gc.Resource{ID: registry.Id, Name: name, Location: folder})
+		}
+	}
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	return r.adapter.Delete(ctx, resource.ID)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+func TestOrphans(t *testing.T) {
+	t.Run("registry without object is orphaned", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		obj := createObject("resource", "folder", "obj", "default")
+		require.NoError(t, cl.Create(ctx, &obj))
+		_, err := rc.allocateResource(ctx, log, &obj)
+		require.NoError(t, err)
+		_, err = ad.Create(ctx, &containerregistry.CreateRegistryRequest{
+			FolderId: "folder",
+			Name:     "orphan",
+			Labels:   map[string]string{config.CloudClusterLabel: "test-cluster", config.CloudNameLabel: "deleted"},
+		})
+		require.NoError(t, err)
+		_, err = ad.Create(ctx, &containerregistry.CreateRegistryRequest{
+			FolderId: "folder",
+			Name:     "foreign",
+			Labels:   map[string]string{config.CloudClusterLabel: "other-cluster", config.CloudNameLabel: "deleted"},
+		})
+		require.NoError(t, err)
+		collector := &orphanCollector{cl: cl, adapter: ad, clusterID: "test-cluster"}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "deleted", orphans[0].Name)
+		assert.Equal(t, "folder", orphans[0].Location)
+	})
+
+	t.Run("configured folders are looked through", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, ad, _ := setup(t)
+		_, err := ad.Create(ctx, &containerregistry.CreateRegistryRequest{
+			FolderId: "other-folder",
+			Name:     "orphan",
+			Labels:   map[string]string{config.CloudClusterLabel: "test-cluster", config.CloudNameLabel: "deleted"},
+		})
+		require.NoError(t, err)
+		collector := &orphanCollector{cl: cl, adapter: ad, clusterID: "test-cluster", folders: []string{"other-folder"}}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, orphans, 1)
+	})
+}
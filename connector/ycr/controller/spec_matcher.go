@@ -12,11 +12,15 @@ import (
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *yandexContainerRegistryReconciler) matchSpec(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry, res *containerregistry.Registry,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "match-spec")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if res.Name == object.Spec.Name {
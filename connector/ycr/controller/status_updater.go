@@ -11,12 +11,16 @@ import (
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
 )
 
 func (r *yandexContainerRegistryReconciler) updateStatus(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry, res *containerregistry.Registry,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "update-status")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if object.Status.ID == res.Id &&
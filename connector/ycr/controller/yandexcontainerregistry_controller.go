@@ -11,14 +11,17 @@ import (
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller/adapter"
 	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 // yandexContainerRegistryReconciler reconciles a YandexContainerRegistry object
@@ -27,15 +30,17 @@ type yandexContainerRegistryReconciler struct {
 	adapter   adapter.YandexContainerRegistryAdapter
 	log       logr.Logger
 	clusterID string
+	recorder  record.EventRecorder
 }
 
 func NewYandexContainerRegistryReconciler(log logr.Logger, cl client.Client,
-	sdk *ycsdk.SDK, clusterID string) *yandexContainerRegistryReconciler {
+	sdk *ycsdk.SDK, clusterID string, recorder record.EventRecorder) *yandexContainerRegistryReconciler {
 	return &yandexContainerRegistryReconciler{
 		Client:    cl,
 		adapter:   adapter.NewYandexContainerRegistryAdapterSDK(sdk),
 		log:       log,
 		clusterID: clusterID,
+		recorder:  recorder,
 	}
 }
 
@@ -43,6 +48,7 @@ func NewYandexContainerRegistryReconciler(log logr.Logger, cl client.Client,
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexcontainerregistries/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexcontainerregistries/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *yandexContainerRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("name", req.NamespacedName)
@@ -62,31 +68,59 @@ func (r *yandexContainerRegistryReconciler) Reconcile(ctx context.Context, req c
 		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
 	}
 
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Update)
+	if err != nil {
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
+	}
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
+	}
+
 	// If object must be currently finalized, do it and quit
 	if phase.MustBeFinalized(&object.ObjectMeta, ycrconfig.FinalizerName) {
 		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
-			return config.GetErroredResult(fmt.Errorf("unable to finalize object: %w", err))
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
 		}
 		return config.GetNormalResult()
 	}
 
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *yandexContainerRegistryReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry,
+) error {
 	if err := phase.RegisterFinalizer(
-		ctx, r.Client, log, &object.ObjectMeta, &object, ycrconfig.FinalizerName,
+		ctx, r.Client, log, &object.ObjectMeta, object, ycrconfig.FinalizerName,
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to register finalizer: %w", err))
+		return fmt.Errorf("unable to register finalizer: %w", err)
 	}
 
-	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), &object)
+	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), object)
 	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to allocate resource: %w", err))
+		return fmt.Errorf("unable to allocate resource: %w", err)
 	}
 
-	if err := r.matchSpec(ctx, log.WithName("match-spec"), &object, res); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to match spec: %w", err))
+	if err := r.matchSpec(ctx, log.WithName("match-spec"), object, res); err != nil {
+		return fmt.Errorf("unable to match spec: %w", err)
 	}
 
-	if err := r.updateStatus(ctx, log.WithName("update-status"), &object, res); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to update status: %w", err))
+	if err := r.updateStatus(ctx, log.WithName("update-status"), object, res); err != nil {
+		return fmt.Errorf("unable to update status: %w", err)
 	}
 
 	if err := phase.ProvideConfigmap(
@@ -96,16 +130,18 @@ func (r *yandexContainerRegistryReconciler) Reconcile(ctx context.Context, req c
 		object.Name, ycrconfig.ShortName, object.Namespace,
 		map[string]string{"ID": object.Status.ID},
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to provide configmap: %w", err))
+		return fmt.Errorf("unable to provide configmap: %w", err)
 	}
 
-	log.V(1).Info("finished reconciliation")
-	return config.GetNormalResult()
+	return nil
 }
 
 func (r *yandexContainerRegistryReconciler) finalize(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexContainerRegistry,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if err := phase.RemoveConfigmap(
@@ -117,7 +153,9 @@ func (r *yandexContainerRegistryReconciler) finalize(
 		return fmt.Errorf("unable to remove configmap: %w", err)
 	}
 
-	if err := r.deallocateResource(ctx, log.WithName("deallocate-resource"), object); err != nil {
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object)
+	}); err != nil {
 		return fmt.Errorf("unable to deallocate resource: %w", err)
 	}
 
@@ -132,8 +170,9 @@ func (r *yandexContainerRegistryReconciler) finalize(
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *yandexContainerRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *yandexContainerRegistryReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&connectorsv1.YandexContainerRegistry{}).
-		Complete(r)
+		WithOptions(opts).
+		Complete(tracing.Reconciler(ycrconfig.LongName, r))
 }
@@ -44,7 +44,6 @@ func GetRegistry(
 		// we will try to list resources and find the one we need.
 	}
 
-	// TODO (covariance) pagination
 	list, err := ad.List(ctx, folderID)
 	if err != nil {
 		// This error is fatal
@@ -5,6 +5,8 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
 // YandexMessageQueueSpec defines the desired state of YandexMessageQueue
@@ -60,15 +62,32 @@ type YandexMessageQueueSpec struct {
 	VisibilityTimeout int `json:"visibilityTimeout"`
 
 	// SAKeyName: specifies name of the Static Access Key that is used to authenticate this
-	// Yandex Object Storage in the cloud.
-	// +kubebuilder:validation:Required
-	SAKeyName string `json:"SAKeyName"`
+	// Yandex Object Storage in the cloud. Exactly one of SAKeyName and SAKeyRef must be set.
+	// +optional
+	SAKeyName string `json:"SAKeyName,omitempty"`
+
+	// SAKeyNamespace: namespace of the Static Access Key given with SAKeyName, namespace of this object
+	// by default. Key of another namespace must be granted to this object with StaticAccessKeyGrant.
+	// +optional
+	SAKeyNamespace string `json:"SAKeyNamespace,omitempty"`
+
+	// SAKeyRef: StaticAccessKey that is used to authenticate this resource in the cloud, resource is
+	// provisioned once the key is ready. Key of another namespace must be granted to this object
+	// with StaticAccessKeyGrant.
+	// +optional
+	SAKeyRef *reference.ObjectReference `json:"SAKeyRef,omitempty"`
 }
 
 // YandexMessageQueueStatus defines the observed state of YandexMessageQueue
 type YandexMessageQueueStatus struct {
 	// URL of created queue
 	QueueURL string `json:"queueUrl,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // YandexMessageQueue is the Schema for the yandex object storage API
@@ -31,7 +31,10 @@ THE SOFTWARE.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -39,8 +42,8 @@ func (in *YandexMessageQueue) DeepCopyInto(out *YandexMessageQueue) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexMessageQueue.
@@ -96,6 +99,11 @@ func (in *YandexMessageQueueList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YandexMessageQueueSpec) DeepCopyInto(out *YandexMessageQueueSpec) {
 	*out = *in
+	if in.SAKeyRef != nil {
+		in, out := &in.SAKeyRef, &out.SAKeyRef
+		*out = new(reference.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexMessageQueueSpec.
@@ -111,6 +119,13 @@ func (in *YandexMessageQueueSpec) DeepCopy() *YandexMessageQueueSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YandexMessageQueueStatus) DeepCopyInto(out *YandexMessageQueueStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexMessageQueueStatus.
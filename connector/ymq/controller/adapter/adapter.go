@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 
 	ymqutil "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
 )
 
@@ -20,41 +21,44 @@ func NewYandexMessageQueueAdapterSDK() YandexMessageQueueAdapter {
 }
 
 func (r *YandexMessageQueueAdapterSDK) Create(
-	_ context.Context, sdk *sqs.SQS, attributes map[string]*string, name string,
+	ctx context.Context, sdk *sqs.SQS, attributes map[string]*string, name string,
 ) (string, error) {
-	res, err := sdk.CreateQueue(
+	res, err := sdk.CreateQueueWithContext(
+		ctx,
 		&sqs.CreateQueueInput{
 			Attributes: attributes,
 			QueueName:  &name,
 		},
 	)
 	if err != nil {
-		return "", err
+		return "", errorhandling.NewCloudError(err, "CreateQueue", name)
 	}
 
 	return *res.QueueUrl, nil
 }
 
-func (r *YandexMessageQueueAdapterSDK) GetURL(_ context.Context, sdk *sqs.SQS, queueName string) (string, error) {
-	res, err := sdk.GetQueueUrl(
+func (r *YandexMessageQueueAdapterSDK) GetURL(ctx context.Context, sdk *sqs.SQS, queueName string) (string, error) {
+	res, err := sdk.GetQueueUrlWithContext(
+		ctx,
 		&sqs.GetQueueUrlInput{
 			QueueName:              &queueName,
 			QueueOwnerAWSAccountId: nil,
 		},
 	)
 	if err != nil {
-		return "", err
+		return "", errorhandling.NewCloudError(err, "GetQueueUrl", queueName)
 	}
 
 	return *res.QueueUrl, nil
 }
 
 func (r *YandexMessageQueueAdapterSDK) GetAttributes(
-	_ context.Context,
+	ctx context.Context,
 	sdk *sqs.SQS,
 	queueURL string,
 ) (map[string]*string, error) {
-	res, err := sdk.GetQueueAttributes(
+	res, err := sdk.GetQueueAttributesWithContext(
+		ctx,
 		&sqs.GetQueueAttributesInput{
 			AttributeNames: []*string{
 				util.StringPtr(ymqutil.FifoQueue),
@@ -69,38 +73,73 @@ func (r *YandexMessageQueueAdapterSDK) GetAttributes(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "GetQueueAttributes", queueURL)
 	}
 
 	return res.Attributes, nil
 }
 
 func (r *YandexMessageQueueAdapterSDK) List(ctx context.Context, sdk *sqs.SQS) ([]*string, error) {
-	res, err := sdk.ListQueues(&sqs.ListQueuesInput{})
+	var res []*string
+	err := sdk.ListQueuesPagesWithContext(ctx, &sqs.ListQueuesInput{}, func(page *sqs.ListQueuesOutput, _ bool) bool {
+		res = append(res, page.QueueUrls...)
+		return true
+	})
 	if err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "ListQueues", "")
 	}
 
-	return res.QueueUrls, nil
+	return res, nil
 }
 
 func (r *YandexMessageQueueAdapterSDK) UpdateAttributes(
-	_ context.Context, sdk *sqs.SQS, attributes map[string]*string, queueURL string,
+	ctx context.Context, sdk *sqs.SQS, attributes map[string]*string, queueURL string,
 ) error {
-	_, err := sdk.SetQueueAttributes(
+	_, err := sdk.SetQueueAttributesWithContext(
+		ctx,
 		&sqs.SetQueueAttributesInput{
 			Attributes: attributes,
 			QueueUrl:   &queueURL,
 		},
 	)
-	return err
+	return errorhandling.NewCloudError(err, "SetQueueAttributes", queueURL)
 }
 
-func (r *YandexMessageQueueAdapterSDK) Delete(_ context.Context, sdk *sqs.SQS, queueURL string) error {
-	_, err := sdk.DeleteQueue(
+func (r *YandexMessageQueueAdapterSDK) GetTags(
+	ctx context.Context, sdk *sqs.SQS, queueURL string,
+) (map[string]*string, error) {
+	res, err := sdk.ListQueueTagsWithContext(
+		ctx,
+		&sqs.ListQueueTagsInput{
+			QueueUrl: &queueURL,
+		},
+	)
+	if err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListQueueTags", queueURL)
+	}
+
+	return res.Tags, nil
+}
+
+func (r *YandexMessageQueueAdapterSDK) Tag(
+	ctx context.Context, sdk *sqs.SQS, queueURL string, tags map[string]*string,
+) error {
+	_, err := sdk.TagQueueWithContext(
+		ctx,
+		&sqs.TagQueueInput{
+			QueueUrl: &queueURL,
+			Tags:     tags,
+		},
+	)
+	return errorhandling.NewCloudError(err, "TagQueue", queueURL)
+}
+
+func (r *YandexMessageQueueAdapterSDK) Delete(ctx context.Context, sdk *sqs.SQS, queueURL string) error {
+	_, err := sdk.DeleteQueueWithContext(
+		ctx,
 		&sqs.DeleteQueueInput{
 			QueueUrl: &queueURL,
 		},
 	)
-	return err
+	return errorhandling.NewCloudError(err, "DeleteQueue", queueURL)
 }
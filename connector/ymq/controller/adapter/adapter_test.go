@@ -0,0 +1,53 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ymqutil "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+)
+
+func TestList(t *testing.T) {
+	t.Run("queues of all pages are listed", func(t *testing.T) {
+		// Arrange
+		pages := map[string]string{
+			"":       `<QueueUrl>https://queue/first</QueueUrl><NextToken>second</NextToken>`,
+			"second": `<QueueUrl>https://queue/second</QueueUrl><NextToken>third</NextToken>`,
+			"third":  `<QueueUrl>https://queue/third</QueueUrl>`,
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			w.Header().Set("Content-Type", "text/xml")
+			_, _ = fmt.Fprintf(
+				w,
+				`<ListQueuesResponse><ListQueuesResult>%s</ListQueuesResult></ListQueuesResponse>`,
+				pages[r.Form.Get("NextToken")],
+			)
+		}))
+		defer server.Close()
+		sdk, err := ymqutil.NewSQSClient(
+			context.TODO(), server.URL, credentials.NewStaticCredentials("key", "secret", ""),
+		)
+		require.NoError(t, err)
+
+		// Act
+		res, err := NewYandexMessageQueueAdapterSDK().List(context.TODO(), sdk)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"https://queue/first", "https://queue/second", "https://queue/third",
+		}, aws.StringValueSlice(res))
+	})
+}
@@ -16,11 +16,13 @@ import (
 
 type FakeYandexMessageQueueAdapter struct {
 	attributes map[string]map[string]*string
+	tags       map[string]map[string]*string
 }
 
 func NewFakeYandexMessageQueueAdapter() YandexMessageQueueAdapter {
 	return &FakeYandexMessageQueueAdapter{
 		attributes: map[string]map[string]*string{},
+		tags:       map[string]map[string]*string{},
 	}
 }
 
@@ -129,6 +131,46 @@ func (r *FakeYandexMessageQueueAdapter) UpdateAttributes(
 	return nil
 }
 
+func (r *FakeYandexMessageQueueAdapter) GetTags(
+	_ context.Context, _ *sqs.SQS, queueURL string,
+) (map[string]*string, error) {
+	name, err := getName(queueURL)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := r.attributes[name]; !exists {
+		return nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "no such queue", nil)
+	}
+
+	tmp := make(map[string]*string)
+	for k, v := range r.tags[name] {
+		s := *v
+		tmp[k] = &s
+	}
+	return tmp, nil
+}
+
+func (r *FakeYandexMessageQueueAdapter) Tag(
+	_ context.Context, _ *sqs.SQS, queueURL string, tags map[string]*string,
+) error {
+	name, err := getName(queueURL)
+	if err != nil {
+		return err
+	}
+	if _, exists := r.attributes[name]; !exists {
+		return awserr.New(sqs.ErrCodeQueueDoesNotExist, "no such queue", nil)
+	}
+
+	if r.tags[name] == nil {
+		r.tags[name] = map[string]*string{}
+	}
+	for k, v := range tags {
+		s := *v
+		r.tags[name][k] = &s
+	}
+	return nil
+}
+
 func (r *FakeYandexMessageQueueAdapter) Delete(_ context.Context, _ *sqs.SQS, queueURL string) error {
 	name, err := getName(queueURL)
 	if err != nil {
@@ -138,5 +180,6 @@ func (r *FakeYandexMessageQueueAdapter) Delete(_ context.Context, _ *sqs.SQS, qu
 		return awserr.New(sqs.ErrCodeQueueDoesNotExist, "no such queue", nil)
 	}
 	delete(r.attributes, name)
+	delete(r.tags, name)
 	return nil
 }
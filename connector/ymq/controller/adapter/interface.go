@@ -15,5 +15,7 @@ type YandexMessageQueueAdapter interface {
 	GetAttributes(ctx context.Context, sdk *sqs.SQS, queueURL string) (map[string]*string, error)
 	List(ctx context.Context, sdk *sqs.SQS) ([]*string, error)
 	UpdateAttributes(ctx context.Context, sdk *sqs.SQS, attributes map[string]*string, queueName string) error
+	GetTags(ctx context.Context, sdk *sqs.SQS, queueURL string) (map[string]*string, error)
+	Tag(ctx context.Context, sdk *sqs.SQS, queueURL string, tags map[string]*string) error
 	Delete(ctx context.Context, sdk *sqs.SQS, queueURL string) error
 }
@@ -7,17 +7,23 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/go-logr/logr"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
 	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *yandexMessageQueueReconciler) allocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue, sdk *sqs.SQS,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	lst, err := r.adapter.List(ctx, sdk)
@@ -26,10 +32,17 @@ func (r *yandexMessageQueueReconciler) allocateResource(
 	}
 	for _, queue := range lst {
 		if *queue == object.Status.QueueURL {
-			return nil
+			return r.tagResource(ctx, log, object, sdk)
 		}
 	}
 
+	if url, ok := object.Annotations[config.AdoptAnnotation]; ok && object.Status.QueueURL == "" {
+		if err := r.adoptResource(ctx, log, object, lst, url); err != nil {
+			return err
+		}
+		return r.tagResource(ctx, log, object, sdk)
+	}
+
 	res, err := r.adapter.Create(ctx, sdk, ymqutils.AttributesFromSpec(&object.Spec), object.Spec.Name)
 	if err != nil {
 		return fmt.Errorf("ubable to create resource: %w", err)
@@ -41,15 +54,61 @@ func (r *yandexMessageQueueReconciler) allocateResource(
 	}
 
 	log.Info("successful")
+	return r.tagResource(ctx, log, object, sdk)
+}
+
+// tagResource marks the queue with id of the cluster and name of the object, so that
+// garbage collector is able to tell the queue is orphaned once the object is gone.
+func (r *yandexMessageQueueReconciler) tagResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue, sdk *sqs.SQS,
+) error {
+	tags, err := r.adapter.GetTags(ctx, sdk, object.Status.QueueURL)
+	if err != nil {
+		return fmt.Errorf("unable to get resource tags: %w", err)
+	}
+	if aws.StringValue(tags[config.CloudClusterLabel]) == r.clusterID &&
+		aws.StringValue(tags[config.CloudNameLabel]) == object.Name {
+		return nil
+	}
+
+	if err := r.adapter.Tag(ctx, sdk, object.Status.QueueURL, map[string]*string{
+		config.CloudClusterLabel: &r.clusterID,
+		config.CloudNameLabel:    &object.Name,
+	}); err != nil {
+		return fmt.Errorf("unable to tag resource: %w", err)
+	}
+
+	log.Info("resource tagged")
 	return nil
 }
 
+// adoptResource takes over existing queue, its attributes are then brought to the spec by the spec matcher.
+func (r *yandexMessageQueueReconciler) adoptResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue, lst []*string, queueURL string,
+) error {
+	for _, queue := range lst {
+		if *queue != queueURL {
+			continue
+		}
+		object.Status.QueueURL = queueURL
+		if err := r.Client.Update(ctx, object); err != nil {
+			return fmt.Errorf("unable to update object status: %w", err)
+		}
+		log.Info("resource adopted", "url", queueURL)
+		return nil
+	}
+	return fmt.Errorf("adopted resource %s does not exist", queueURL)
+}
+
 func (r *yandexMessageQueueReconciler) deallocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue, sdk *sqs.SQS,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
-	err := r.adapter.Delete(ctx, sdk, object.Status.QueueURL)
+	err = r.adapter.Delete(ctx, sdk, object.Status.QueueURL)
 	if err != nil {
 		if awsutils.CheckSQSDoesNotExist(err) {
 			log.Info("already deleted")
@@ -8,6 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
 )
 
 func TestAllocate(t *testing.T) {
@@ -88,6 +92,61 @@ func TestAllocate(t *testing.T) {
 		assert.Error(t, err1)
 		assert.Len(t, lst, 1)
 	})
+
+	t.Run("allocate tags resource with cluster id and object name", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createDefaultQueue("obj", "default", "sakey", "queue")
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		require.NoError(t, rc.allocateResource(ctx, log, &obj, nil))
+		tags, err := ad.GetTags(ctx, nil, obj.Status.QueueURL)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "test-cluster", *tags[config.CloudClusterLabel])
+		assert.Equal(t, "obj", *tags[config.CloudNameLabel])
+	})
+}
+
+func TestAdopt(t *testing.T) {
+	t.Run("allocate of adopted queue keeps its attributes for spec matcher", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		url, err := ad.Create(ctx, nil, map[string]*string{ymqutils.DelaySeconds: util.StringPtr("10")}, "queue")
+		require.NoError(t, err)
+		obj := createDefaultQueue("obj", "default", "sakey", "queue")
+		obj.Annotations = map[string]string{config.AdoptAnnotation: url}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		require.NoError(t, rc.allocateResource(ctx, log, &obj, nil))
+		lst, err := ad.List(ctx, nil)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Len(t, lst, 1)
+		assert.Equal(t, url, obj.Status.QueueURL)
+	})
+
+	t.Run("missing adopted queue is an error", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, _, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createDefaultQueue("obj", "default", "sakey", "queue")
+		obj.Annotations = map[string]string{config.AdoptAnnotation: "https://example.com/missing"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		err := rc.allocateResource(ctx, log, &obj, nil)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, obj.Status.QueueURL)
+	})
 }
 
 func TestDeallocate(t *testing.T) {
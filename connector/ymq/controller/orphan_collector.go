@@ -0,0 +1,136 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller/adapter"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+)
+
+// orphanCollector finds queues tagged with this cluster id that are not queues of any
+// YandexMessageQueue object. Queues are listed with static access keys, as there is no
+// other way to reach them.
+type orphanCollector struct {
+	cl        client.Reader
+	adapter   adapter.YandexMessageQueueAdapter
+	endpoint  string
+	clusterID string
+	keys      []types.NamespacedName
+	clients   *awsutils.ClientCache
+
+	// sdks are clients orphans were found with by the last run, by queue url
+	sdks map[string]*sqs.SQS
+}
+
+// NewOrphanCollector creates collector that lists queues with the given keys
+// and keys of all existing YandexMessageQueue objects.
+func NewOrphanCollector(
+	cl client.Reader, endpoint string, clients *awsutils.ClientCache, clusterID string, keys []types.NamespacedName,
+) gc.Collector {
+	return &orphanCollector{
+		cl:        cl,
+		adapter:   adapter.NewYandexMessageQueueAdapterSDK(),
+		endpoint:  endpoint,
+		clusterID: clusterID,
+		keys:      keys,
+		clients:   clients,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return ymqconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.YandexMessageQueueList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	keys := append([]types.NamespacedName{}, r.keys...)
+	seen := map[types.NamespacedName]bool{}
+	for _, key := range keys {
+		seen[key] = true
+	}
+	backed := map[string]bool{}
+	for _, object := range objects.Items {
+		key := awsutils.StaticAccessKeyName(
+			object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+		)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		backed[object.Status.QueueURL] = true
+	}
+
+	listed := map[string]bool{}
+	sdks := map[string]*sqs.SQS{}
+	var res []gc.Resource
+	for _, key := range keys {
+		cred, err := r.clients.Credentials(ctx, key, r.cl)
+		if err != nil {
+			// Key may be deleted together with objects that used it
+			if awsutils.CheckCredentialsGone(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to retrieve credentials of key %s: %w", key, err)
+		}
+		sdk, err := ymqutils.SharedSQSClient(ctx, r.clients, r.endpoint, cred)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build sdk: %w", err)
+		}
+
+		lst, err := r.adapter.List(ctx, sdk)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list queues with key %s: %w", key, err)
+		}
+		for _, url := range lst {
+			// Several keys may reach the same queue
+			if listed[*url] || backed[*url] {
+				continue
+			}
+			listed[*url] = true
+			tags, err := r.adapter.GetTags(ctx, sdk, *url)
+			if err != nil {
+				if awsutils.CheckSQSDoesNotExist(err) {
+					continue
+				}
+				return nil, fmt.Errorf("unable to get tags of queue %s: %w", *url, err)
+			}
+			if aws.StringValue(tags[config.CloudClusterLabel]) != r.clusterID {
+				continue
+			}
+			sdks[*url] = sdk
+			res = append(res, gc.Resource{
+				ID:       *url,
+				Name:     aws.StringValue(tags[config.CloudNameLabel]),
+				Location: key.String(),
+			})
+		}
+	}
+	r.sdks = sdks
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	sdk, ok := r.sdks[resource.ID]
+	if !ok {
+		return fmt.Errorf("queue %s was not found by the last run", resource.ID)
+	}
+	return r.adapter.Delete(ctx, sdk, resource.ID)
+}
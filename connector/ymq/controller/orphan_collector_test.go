@@ -0,0 +1,102 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+func TestOrphans(t *testing.T) {
+	t.Run("tagged queue without object is orphaned", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createDefaultQueue("obj", "default", "sakey", "queue")
+		require.NoError(t, cl.Create(ctx, &obj))
+		require.NoError(t, rc.allocateResource(ctx, log, &obj, nil))
+		orphan, err := ad.Create(ctx, nil, map[string]*string{}, "orphan")
+		require.NoError(t, err)
+		require.NoError(t, ad.Tag(ctx, nil, orphan, map[string]*string{
+			config.CloudClusterLabel: util.StringPtr("test-cluster"), config.CloudNameLabel: util.StringPtr("deleted"),
+		}))
+		foreign, err := ad.Create(ctx, nil, map[string]*string{}, "foreign")
+		require.NoError(t, err)
+		require.NoError(t, ad.Tag(ctx, nil, foreign, map[string]*string{
+			config.CloudClusterLabel: util.StringPtr("other-cluster"), config.CloudNameLabel: util.StringPtr("deleted"),
+		}))
+		_, err = ad.Create(ctx, nil, map[string]*string{}, "untagged")
+		require.NoError(t, err)
+		collector := &orphanCollector{
+			cl: cl, adapter: ad, endpoint: ymqconfig.DefaultEndpoint, clusterID: "test-cluster", clients: rc.clients,
+		}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, orphan, orphans[0].ID)
+		assert.Equal(t, "deleted", orphans[0].Name)
+		assert.Equal(t, "default/sakey", orphans[0].Location)
+	})
+
+	t.Run("orphan is deleted with key it was found with", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		orphan, err := ad.Create(ctx, nil, map[string]*string{}, "orphan")
+		require.NoError(t, err)
+		require.NoError(t, ad.Tag(ctx, nil, orphan, map[string]*string{
+			config.CloudClusterLabel: util.StringPtr("test-cluster"), config.CloudNameLabel: util.StringPtr("deleted"),
+		}))
+		collector := &orphanCollector{
+			cl:        cl,
+			adapter:   ad,
+			endpoint:  ymqconfig.DefaultEndpoint,
+			clusterID: "test-cluster",
+			keys:      []types.NamespacedName{{Namespace: "default", Name: "sakey"}},
+			clients:   rc.clients,
+		}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		require.NoError(t, collector.Delete(ctx, orphans[0]))
+		lst, err := ad.List(ctx, nil)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Empty(t, lst)
+	})
+
+	t.Run("deleted key is skipped", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, ad, rc := setup(t)
+		collector := &orphanCollector{
+			cl:        cl,
+			adapter:   ad,
+			endpoint:  ymqconfig.DefaultEndpoint,
+			clusterID: "test-cluster",
+			keys:      []types.NamespacedName{{Namespace: "default", Name: "sakey"}},
+			clients:   rc.clients,
+		}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, orphans)
+	})
+}
@@ -12,11 +12,15 @@ import (
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
 	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *yandexMessageQueueReconciler) matchSpec(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue, sdk *sqs.SQS,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "match-spec")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	attributes := ymqutils.AttributesFromSpec(&object.Spec)
@@ -7,12 +7,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller/adapter"
 	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
@@ -20,28 +26,52 @@ import (
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 // yandexMessageQueueReconciler reconciles a YandexContainerRegistry object
 type yandexMessageQueueReconciler struct {
 	client.Client
-	adapter adapter.YandexMessageQueueAdapter
-	log     logr.Logger
+	adapter   adapter.YandexMessageQueueAdapter
+	log       logr.Logger
+	endpoint  string
+	clusterID string
+	recorder  record.EventRecorder
+
+	// credentials keeps last retrieved credentials of objects for their cleanup
+	credentials *awsutils.CredentialsCache
+	// clients are shared with other connectors and webhooks
+	clients *awsutils.ClientCache
 }
 
-func NewYandexMessageQueueReconciler(cl client.Client, log logr.Logger) *yandexMessageQueueReconciler {
+func NewYandexMessageQueueReconciler(
+	cl client.Client,
+	log logr.Logger,
+	endpoint string,
+	clusterID string,
+	recorder record.EventRecorder,
+	clients *awsutils.ClientCache,
+) *yandexMessageQueueReconciler {
 	return &yandexMessageQueueReconciler{
-		Client:  cl,
-		adapter: adapter.NewYandexMessageQueueAdapterSDK(),
-		log:     log,
+		Client:    cl,
+		adapter:   adapter.NewYandexMessageQueueAdapterSDK(),
+		log:       log,
+		endpoint:  endpoint,
+		clusterID: clusterID,
+		recorder:  recorder,
+
+		credentials: awsutils.NewCredentialsCache(),
+		clients:     clients,
 	}
 }
 
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexmessagequeues,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexmessagequeues/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexmessagequeues/finalizers,verbs=update
-// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=get
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeygrants,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *yandexMessageQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("name", req.NamespacedName)
@@ -61,35 +91,64 @@ func (r *yandexMessageQueueReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
 	}
 
-	cred, err := awsutils.CredentialsFromStaticAccessKey(ctx, object.Namespace, object.Spec.SAKeyName, r.Client)
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Update)
 	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to retrieve credentials: %w", err))
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
 	}
-	sdk, err := ymqutils.NewSQSClient(ctx, cred)
-	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to build sdk: %w", err))
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
 	}
 
-	// If object must be currently finalized, do it and quit
+	// If object must be currently finalized, do it and quit. Credentials are retrieved during
+	// finalization only if they are needed, so that orphaned objects can be deleted without them.
 	if phase.MustBeFinalized(&object.ObjectMeta, ymqconfig.FinalizerName) {
-		if err := r.finalize(ctx, log.WithName("finalize"), &object, sdk); err != nil {
-			return config.GetErroredResult(fmt.Errorf("unable to finalize object: %w", err))
+		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
 		}
 		return config.GetNormalResult()
 	}
 
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *yandexMessageQueueReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue,
+) error {
+	if err := r.resolveReferences(ctx, log.WithName("resolve-references"), object); err != nil {
+		return fmt.Errorf("unable to resolve references: %w", err)
+	}
+
+	sdk, err := r.newSDK(ctx, object)
+	if err != nil {
+		return err
+	}
+
 	if err := phase.RegisterFinalizer(
-		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, &object, ymqconfig.FinalizerName,
+		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, object, ymqconfig.FinalizerName,
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to register finalizer: %w", err))
+		return fmt.Errorf("unable to register finalizer: %w", err)
 	}
 
-	if err := r.allocateResource(ctx, log.WithName("allocate-resource"), &object, sdk); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to allocate resource: %w", err))
+	if err := r.allocateResource(ctx, log.WithName("allocate-resource"), object, sdk); err != nil {
+		return fmt.Errorf("unable to allocate resource: %w", err)
 	}
 
-	if err := r.matchSpec(ctx, log.WithName("match-spec"), &object, sdk); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to match spec: %w", err))
+	if err := r.matchSpec(ctx, log.WithName("match-spec"), object, sdk); err != nil {
+		return fmt.Errorf("unable to match spec: %w", err)
 	}
 
 	if err := phase.ProvideConfigmap(
@@ -99,19 +158,20 @@ func (r *yandexMessageQueueReconciler) Reconcile(ctx context.Context, req ctrl.R
 		object.Name, ymqconfig.ShortName, object.Namespace,
 		map[string]string{"URL": object.Status.QueueURL},
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to provide configmap: %w", err))
+		return fmt.Errorf("unable to provide configmap: %w", err)
 	}
 
-	log.V(1).Info("finished reconciliation")
-	return config.GetNormalResult()
+	return nil
 }
 
 func (r *yandexMessageQueueReconciler) finalize(
 	ctx context.Context,
 	log logr.Logger,
 	object *connectorsv1.YandexMessageQueue,
-	sdk *sqs.SQS,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if err := phase.RemoveConfigmap(
@@ -123,7 +183,13 @@ func (r *yandexMessageQueueReconciler) finalize(
 		return fmt.Errorf("unable to remove configmap: %w", err)
 	}
 
-	if err := r.deallocateResource(ctx, log.WithName("deallocate-resource"), object, sdk); err != nil {
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		sdk, err := r.cleanupSDK(ctx, log, object)
+		if err != nil {
+			return err
+		}
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object, sdk)
+	}); err != nil {
 		return fmt.Errorf("unable to deallocate resource: %w", err)
 	}
 
@@ -132,14 +198,75 @@ func (r *yandexMessageQueueReconciler) finalize(
 	); err != nil {
 		return fmt.Errorf("unable to deregister finalizer: %w", err)
 	}
+	r.credentials.Forget(object.UID)
 
 	log.Info("successful")
 	return nil
 }
 
+func (r *yandexMessageQueueReconciler) newSDK(ctx context.Context, object *connectorsv1.YandexMessageQueue) (*sqs.SQS, error) {
+	cred, err := r.retrieveCredentials(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildSDK(ctx, cred)
+}
+
+// cleanupSDK builds sdk for cleanup of cloud resource. If credentials of the object are gone, e.g. because
+// its key was deleted first during namespace deletion, last retrieved credentials are used instead.
+// Without them cleanup is impossible and the object is finalized as orphaned.
+func (r *yandexMessageQueueReconciler) cleanupSDK(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexMessageQueue,
+) (*sqs.SQS, error) {
+	cred, err := r.retrieveCredentials(ctx, object)
+	if err != nil {
+		if !awsutils.CheckCredentialsGone(err) {
+			return nil, err
+		}
+		cached, ok := r.credentials.Get(object.UID)
+		if !ok {
+			return nil, phase.CleanupImpossible(err)
+		}
+		log.Info("credentials are gone, last retrieved ones are used for cleanup", "reason", err.Error())
+		cred = cached
+	}
+	return r.buildSDK(ctx, cred)
+}
+
+func (r *yandexMessageQueueReconciler) retrieveCredentials(
+	ctx context.Context, object *connectorsv1.YandexMessageQueue,
+) (*credentials.Credentials, error) {
+	key := awsutils.StaticAccessKeyName(
+		object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+	)
+	cred, err := r.clients.GrantedCredentials(ctx, ymqconfig.LongName, object.Namespace, key, r.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+	r.credentials.Put(object.UID, cred)
+	return cred, nil
+}
+
+func (r *yandexMessageQueueReconciler) buildSDK(ctx context.Context, cred *credentials.Credentials) (*sqs.SQS, error) {
+	sdk, err := ymqutils.SharedSQSClient(ctx, r.clients, r.endpoint, cred)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build sdk: %w", err)
+	}
+	return sdk, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
-func (r *yandexMessageQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *yandexMessageQueueReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&connectorsv1.YandexMessageQueue{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &sakey.StaticAccessKey{}},
+			handler.EnqueueRequestsFromMapFunc(r.objectsOfStaticAccessKey),
+		).
+		Watches(
+			&source.Kind{Type: &sakey.StaticAccessKeyGrant{}},
+			handler.EnqueueRequestsFromMapFunc(r.objectsOfStaticAccessKeyGrant),
+		).
+		WithOptions(opts).
+		Complete(tracing.Reconciler(ymqconfig.LongName, r))
 }
@@ -4,9 +4,9 @@
 package config
 
 const (
-	AWSRegion     = "ru-central1"
-	Endpoint      = "message-queue.api.cloud.yandex.net"
-	FinalizerName = "finalizer.ymq.connectors.cloud.yandex.com"
-	LongName      = "YandexMessageQueue"
-	ShortName     = "ymq"
+	AWSRegion       = "ru-central1"
+	DefaultEndpoint = "message-queue.api.cloud.yandex.net"
+	FinalizerName   = "finalizer.ymq.connectors.cloud.yandex.com"
+	LongName        = "YandexMessageQueue"
+	ShortName       = "ymq"
 )
@@ -14,16 +14,18 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
-func NewSQSClient(_ context.Context, cred *credentials.Credentials) (*sqs.SQS, error) {
+func NewSQSClient(_ context.Context, endpoint string, cred *credentials.Credentials) (*sqs.SQS, error) {
 	ses, err := session.NewSession(
 		&aws.Config{
 			Credentials: cred,
-			Endpoint:    aws.String(config.Endpoint),
+			Endpoint:    aws.String(endpoint),
 			EndpointResolver: endpoints.ResolverFunc(
 				func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-					return endpoints.ResolvedEndpoint{URL: config.Endpoint}, nil
+					return endpoints.ResolvedEndpoint{URL: endpoint}, nil
 				},
 			),
 			Region:           aws.String(config.AWSRegion),
@@ -35,5 +37,19 @@ func NewSQSClient(_ context.Context, cred *credentials.Credentials) (*sqs.SQS, e
 		return nil, fmt.Errorf("unable to get %s sdk: %w", config.ShortName, err)
 	}
 
+	tracing.InstrumentAWS(&ses.Handlers)
 	return sqs.New(ses), nil
 }
+
+// SharedSQSClient returns SQS client built with the credentials, which is shared through the cache.
+func SharedSQSClient(
+	ctx context.Context, clients *awsutils.ClientCache, endpoint string, cred *credentials.Credentials,
+) (*sqs.SQS, error) {
+	sdk, err := clients.Client(cred, endpoint, func() (interface{}, error) {
+		return NewSQSClient(ctx, endpoint, cred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.(*sqs.SQS), nil
+}
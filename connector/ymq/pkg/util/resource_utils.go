@@ -4,6 +4,8 @@
 package util
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
@@ -35,3 +37,43 @@ func AttributesFromSpec(spec *connectorsv1.YandexMessageQueueSpec) map[string]*s
 	}
 	return attributes
 }
+
+// SpecFromAttributes is the inverse of AttributesFromSpec, it also returns names
+// of the attributes that spec cannot represent. SAKeyName is left empty.
+func SpecFromAttributes(
+	name string, attributes map[string]*string,
+) (*connectorsv1.YandexMessageQueueSpec, []string, error) {
+	spec := &connectorsv1.YandexMessageQueueSpec{Name: name}
+	ints := map[string]*int{
+		DelaySeconds:                  &spec.DelaySeconds,
+		MaximumMessageSize:            &spec.MaximumMessageSize,
+		MessageRetentionPeriod:        &spec.MessageRetentionPeriod,
+		ReceiveMessageWaitTimeSeconds: &spec.ReceiveMessageWaitTimeSeconds,
+		VisibilityTimeout:             &spec.VisibilityTimeout,
+	}
+	bools := map[string]*bool{
+		FifoQueue:                 &spec.FifoQueue,
+		ContentBasedDeduplication: &spec.ContentBasedDeduplication,
+	}
+
+	var unsupported []string
+	for k, v := range attributes {
+		if v == nil {
+			continue
+		}
+		var err error
+		switch {
+		case ints[k] != nil:
+			*ints[k], err = strconv.Atoi(*v)
+		case bools[k] != nil:
+			*bools[k], err = strconv.ParseBool(*v)
+		default:
+			unsupported = append(unsupported, k)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse attribute %s: %w", k, err)
+		}
+	}
+	sort.Strings(unsupported)
+	return spec, unsupported, nil
+}
@@ -9,6 +9,11 @@ import (
 	"strings"
 
 	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -53,18 +58,33 @@ func (r *YMQValidator) ValidateCreation(ctx context.Context, log logr.Logger, ob
 		)
 	}
 
+	if (casted.Spec.SAKeyName == "") == (casted.Spec.SAKeyRef == nil) {
+		return webhook.NewValidationErrorf("exactly one of SAKeyName and SAKeyRef must be set")
+	}
+	// Referenced StaticAccessKey may be created and granted later, resource waits for it
+	if ref := casted.Spec.SAKeyRef; ref != nil {
+		if casted.Spec.SAKeyNamespace != "" {
+			return webhook.NewValidationErrorf("SAKeyNamespace must not be set with SAKeyRef, set namespace of SAKeyRef")
+		}
+		if err := ref.Validate(sakeyconfig.LongName); err != nil {
+			return webhook.NewValidationError(err)
+		}
+		return nil
+	}
+
+	name := awsutils.StaticAccessKeyName(casted.Namespace, casted.Spec.SAKeyNamespace, casted.Spec.SAKeyName, nil)
+	if err := sakeyutils.CheckGrant(ctx, r.cl, ymqconfig.LongName, casted.Namespace, name); err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotGranted) {
+			return webhook.NewValidationError(err)
+		}
+		return err
+	}
+
 	var key sakey.StaticAccessKey
-	if err := r.cl.Get(
-		ctx,
-		client.ObjectKey{
-			Name:      casted.Spec.SAKeyName,
-			Namespace: casted.Namespace,
-		},
-		&key,
-	); err != nil {
+	if err := r.cl.Get(ctx, name, &key); err != nil {
 		if errors.IsNotFound(err) {
 			return webhook.NewValidationErrorf(
-				"static access key \"%s\" not found in the %s namespace", casted.Spec.SAKeyName, casted.Namespace,
+				"static access key \"%s\" not found in the %s namespace", name.Name, name.Namespace,
 			)
 		}
 		return fmt.Errorf("unable to get specified static access key: %w", err)
@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
 	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
 )
@@ -206,6 +207,29 @@ func TestCreateValidation(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
 	})
+
+	t.Run("create with both SAKey name and reference is invalid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, cl := setupValidation(t)
+		obj := v1.YandexMessageQueue{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1.YandexMessageQueueSpec{
+				Name:      "q",
+				SAKeyName: "real-sakey",
+				SAKeyRef:  &reference.ObjectReference{Kind: "StaticAccessKey", Name: "real-sakey"},
+			},
+		}
+		createSAKey(ctx, t, cl, "real-sakey", "some-namespace")
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
 }
 
 func TestUpdateValidation(t *testing.T) {
@@ -31,7 +31,10 @@ THE SOFTWARE.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -39,8 +42,8 @@ func (in *YandexObjectStorage) DeepCopyInto(out *YandexObjectStorage) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexObjectStorage.
@@ -96,6 +99,11 @@ func (in *YandexObjectStorageList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YandexObjectStorageSpec) DeepCopyInto(out *YandexObjectStorageSpec) {
 	*out = *in
+	if in.SAKeyRef != nil {
+		in, out := &in.SAKeyRef, &out.SAKeyRef
+		*out = new(reference.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexObjectStorageSpec.
@@ -111,6 +119,13 @@ func (in *YandexObjectStorageSpec) DeepCopy() *YandexObjectStorageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YandexObjectStorageStatus) DeepCopyInto(out *YandexObjectStorageStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YandexObjectStorageStatus.
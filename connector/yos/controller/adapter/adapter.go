@@ -6,7 +6,11 @@ package adapter
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
 )
 
 type YandexObjectStorageAdapterSDK struct{}
@@ -15,29 +19,71 @@ func NewYandexObjectStorageAdapterSDK() (YandexObjectStorageAdapter, error) {
 	return &YandexObjectStorageAdapterSDK{}, nil
 }
 
-func (r *YandexObjectStorageAdapterSDK) Create(_ context.Context, sdk *s3.S3, name string) error {
-	_, err := sdk.CreateBucket(
+func (r *YandexObjectStorageAdapterSDK) Create(ctx context.Context, sdk *s3.S3, name string) error {
+	_, err := sdk.CreateBucketWithContext(
+		ctx,
 		&s3.CreateBucketInput{
 			Bucket: &name,
 		},
 	)
-	return err
+	return errorhandling.NewCloudError(err, "CreateBucket", name)
 }
 
-func (r *YandexObjectStorageAdapterSDK) List(_ context.Context, sdk *s3.S3) ([]*s3.Bucket, error) {
-	res, err := sdk.ListBuckets(&s3.ListBucketsInput{})
+func (r *YandexObjectStorageAdapterSDK) List(ctx context.Context, sdk *s3.S3) ([]*s3.Bucket, error) {
+	res, err := sdk.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
 	if err != nil {
-		return nil, err
+		return nil, errorhandling.NewCloudError(err, "ListBuckets", "")
 	}
 
 	return res.Buckets, nil
 }
 
-func (r *YandexObjectStorageAdapterSDK) Delete(_ context.Context, sdk *s3.S3, name string) error {
-	_, err := sdk.DeleteBucket(
+// GetTags returns tags of the bucket, bucket without tags has empty set of them.
+func (r *YandexObjectStorageAdapterSDK) GetTags(ctx context.Context, sdk *s3.S3, name string) (map[string]string, error) {
+	res, err := sdk.GetBucketTaggingWithContext(
+		ctx,
+		&s3.GetBucketTaggingInput{
+			Bucket: &name,
+		},
+	)
+	if err != nil {
+		if awsutils.CheckS3NoSuchTagSet(err) {
+			return map[string]string{}, nil
+		}
+		return nil, errorhandling.NewCloudError(err, "GetBucketTagging", name)
+	}
+
+	tags := map[string]string{}
+	for _, tag := range res.TagSet {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// SetTags replaces all tags of the bucket.
+func (r *YandexObjectStorageAdapterSDK) SetTags(
+	ctx context.Context, sdk *s3.S3, name string, tags map[string]string,
+) error {
+	var tagSet []*s3.Tag
+	for k, v := range tags {
+		tagSet = append(tagSet, &s3.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	_, err := sdk.PutBucketTaggingWithContext(
+		ctx,
+		&s3.PutBucketTaggingInput{
+			Bucket:  &name,
+			Tagging: &s3.Tagging{TagSet: tagSet},
+		},
+	)
+	return errorhandling.NewCloudError(err, "PutBucketTagging", name)
+}
+
+func (r *YandexObjectStorageAdapterSDK) Delete(ctx context.Context, sdk *s3.S3, name string) error {
+	_, err := sdk.DeleteBucketWithContext(
+		ctx,
 		&s3.DeleteBucketInput{
 			Bucket: &name,
 		},
 	)
-	return err
+	return errorhandling.NewCloudError(err, "DeleteBucket", name)
 }
@@ -14,11 +14,13 @@ import (
 
 type FakeYandexObjectStorageAdapter struct {
 	storage map[string]s3.Bucket
+	tags    map[string]map[string]string
 }
 
 func NewFakeYandexObjectStorageAdapter() YandexObjectStorageAdapter {
 	return &FakeYandexObjectStorageAdapter{
 		make(map[string]s3.Bucket),
+		make(map[string]map[string]string),
 	}
 }
 
@@ -49,12 +51,37 @@ func (r *FakeYandexObjectStorageAdapter) List(_ context.Context, _ *s3.S3) ([]*s
 	return lst, nil
 }
 
+func (r *FakeYandexObjectStorageAdapter) GetTags(_ context.Context, _ *s3.S3, name string) (map[string]string, error) {
+	if _, exists := r.storage[name]; !exists {
+		return nil, awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil)
+	}
+
+	tags := make(map[string]string)
+	for k, v := range r.tags[name] {
+		tags[k] = v
+	}
+	return tags, nil
+}
+
+func (r *FakeYandexObjectStorageAdapter) SetTags(_ context.Context, _ *s3.S3, name string, tags map[string]string) error {
+	if _, exists := r.storage[name]; !exists {
+		return awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil)
+	}
+
+	r.tags[name] = make(map[string]string)
+	for k, v := range tags {
+		r.tags[name][k] = v
+	}
+	return nil
+}
+
 func (r *FakeYandexObjectStorageAdapter) Delete(_ context.Context, _ *s3.S3, name string) error {
 	if _, exists := r.storage[name]; !exists {
 		return awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil)
 	}
 
 	delete(r.storage, name)
+	delete(r.tags, name)
 
 	return nil
 }
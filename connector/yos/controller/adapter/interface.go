@@ -12,5 +12,7 @@ import (
 type YandexObjectStorageAdapter interface {
 	Create(ctx context.Context, sdk *s3.S3, name string) error
 	List(ctx context.Context, sdk *s3.S3) ([]*s3.Bucket, error)
+	GetTags(ctx context.Context, sdk *s3.S3, name string) (map[string]string, error)
+	SetTags(ctx context.Context, sdk *s3.S3, name string, tags map[string]string) error
 	Delete(ctx context.Context, sdk *s3.S3, name string) error
 }
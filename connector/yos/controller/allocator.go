@@ -12,6 +12,8 @@ import (
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func (r *yandexObjectStorageReconciler) allocateResource(
@@ -19,7 +21,10 @@ func (r *yandexObjectStorageReconciler) allocateResource(
 	log logr.Logger,
 	object *connectorsv1.YandexObjectStorage,
 	sdk *s3.S3,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	lst, err := r.adapter.List(ctx, sdk)
@@ -29,7 +34,7 @@ func (r *yandexObjectStorageReconciler) allocateResource(
 	for _, bucket := range lst {
 		if *bucket.Name == object.Name {
 			log.V(1).Info("bucket found")
-			return nil
+			return r.tagResource(ctx, log, object, sdk)
 		}
 	}
 
@@ -40,15 +45,42 @@ func (r *yandexObjectStorageReconciler) allocateResource(
 		return fmt.Errorf("unable to create resource: %w", err)
 	}
 	log.Info("successful")
+	return r.tagResource(ctx, log, object, sdk)
+}
+
+// tagResource marks the bucket with id of the cluster and name of the object, so that
+// garbage collector is able to tell the bucket is orphaned once the object is gone.
+// Other tags of the bucket are kept.
+func (r *yandexObjectStorageReconciler) tagResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexObjectStorage, sdk *s3.S3,
+) error {
+	tags, err := r.adapter.GetTags(ctx, sdk, object.Spec.Name)
+	if err != nil {
+		return fmt.Errorf("unable to get resource tags: %w", err)
+	}
+	if tags[config.CloudClusterLabel] == r.clusterID && tags[config.CloudNameLabel] == object.Name {
+		return nil
+	}
+
+	tags[config.CloudClusterLabel] = r.clusterID
+	tags[config.CloudNameLabel] = object.Name
+	if err := r.adapter.SetTags(ctx, sdk, object.Spec.Name, tags); err != nil {
+		return fmt.Errorf("unable to tag resource: %w", err)
+	}
+
+	log.Info("resource tagged")
 	return nil
 }
 
 func (r *yandexObjectStorageReconciler) deallocateResource(
 	ctx context.Context, log logr.Logger, object *connectorsv1.YandexObjectStorage, sdk *s3.S3,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
-	err := r.adapter.Delete(ctx, sdk, object.Spec.Name)
+	err = r.adapter.Delete(ctx, sdk, object.Spec.Name)
 	if err != nil {
 		if awsutils.CheckS3DoesNotExist(err) {
 			log.Info("already deleted")
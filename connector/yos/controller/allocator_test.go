@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 )
 
 func TestAllocate(t *testing.T) {
@@ -68,6 +70,28 @@ func TestAllocate(t *testing.T) {
 		assert.Error(t, err1)
 		assert.Len(t, lst, 1)
 	})
+
+	t.Run("allocate tags resource keeping its other tags", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createObject("bucket", "sakey", "", "bucket", "default")
+		require.NoError(t, cl.Create(ctx, &obj))
+		require.NoError(t, ad.Create(ctx, nil, "bucket"))
+		require.NoError(t, ad.SetTags(ctx, nil, "bucket", map[string]string{"team": "a"}))
+
+		// Act
+		require.NoError(t, rc.allocateResource(ctx, log, &obj, nil))
+		tags, err := ad.GetTags(ctx, nil, "bucket")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			config.CloudClusterLabel: "test-cluster",
+			config.CloudNameLabel:    "bucket",
+			"team":                   "a",
+		}, tags)
+	})
 }
 
 func TestDeallocate(t *testing.T) {
@@ -0,0 +1,132 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller/adapter"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	yosutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+)
+
+// orphanCollector finds buckets tagged with this cluster id that are not buckets of any
+// YandexObjectStorage object. Buckets are listed with static access keys, as there is no
+// other way to reach them.
+type orphanCollector struct {
+	cl        client.Reader
+	adapter   adapter.YandexObjectStorageAdapter
+	endpoint  string
+	clusterID string
+	keys      []types.NamespacedName
+	clients   *awsutils.ClientCache
+
+	// sdks are clients orphans were found with by the last run, by bucket name
+	sdks map[string]*s3.S3
+}
+
+// NewOrphanCollector creates collector that lists buckets with the given keys
+// and keys of all existing YandexObjectStorage objects.
+func NewOrphanCollector(
+	cl client.Reader, endpoint string, clients *awsutils.ClientCache, clusterID string, keys []types.NamespacedName,
+) gc.Collector {
+	return &orphanCollector{
+		cl:        cl,
+		adapter:   &adapter.YandexObjectStorageAdapterSDK{},
+		endpoint:  endpoint,
+		clusterID: clusterID,
+		keys:      keys,
+		clients:   clients,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return yosconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.YandexObjectStorageList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	keys := append([]types.NamespacedName{}, r.keys...)
+	seen := map[types.NamespacedName]bool{}
+	for _, key := range keys {
+		seen[key] = true
+	}
+	backed := map[string]bool{}
+	for _, object := range objects.Items {
+		key := awsutils.StaticAccessKeyName(
+			object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+		)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		backed[object.Spec.Name] = true
+	}
+
+	listed := map[string]bool{}
+	sdks := map[string]*s3.S3{}
+	var res []gc.Resource
+	for _, key := range keys {
+		cred, err := r.clients.Credentials(ctx, key, r.cl)
+		if err != nil {
+			// Key may be deleted together with objects that used it
+			if awsutils.CheckCredentialsGone(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to retrieve credentials of key %s: %w", key, err)
+		}
+		sdk, err := yosutils.SharedS3Client(ctx, r.clients, r.endpoint, cred)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build sdk: %w", err)
+		}
+
+		lst, err := r.adapter.List(ctx, sdk)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list buckets with key %s: %w", key, err)
+		}
+		for _, bucket := range lst {
+			name := *bucket.Name
+			// Several keys may reach the same bucket
+			if listed[name] || backed[name] {
+				continue
+			}
+			listed[name] = true
+			tags, err := r.adapter.GetTags(ctx, sdk, name)
+			if err != nil {
+				if awsutils.CheckS3DoesNotExist(err) {
+					continue
+				}
+				return nil, fmt.Errorf("unable to get tags of bucket %s: %w", name, err)
+			}
+			if tags[config.CloudClusterLabel] != r.clusterID {
+				continue
+			}
+			sdks[name] = sdk
+			res = append(res, gc.Resource{ID: name, Name: tags[config.CloudNameLabel], Location: key.String()})
+		}
+	}
+	r.sdks = sdks
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	sdk, ok := r.sdks[resource.ID]
+	if !ok {
+		return fmt.Errorf("bucket %s was not found by the last run", resource.ID)
+	}
+	return r.adapter.Delete(ctx, sdk, resource.ID)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+func TestOrphans(t *testing.T) {
+	t.Run("tagged bucket without object is orphaned", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createObject("bucket", "sakey", "", "obj", "default")
+		require.NoError(t, cl.Create(ctx, &obj))
+		require.NoError(t, rc.allocateResource(ctx, log, &obj, nil))
+		require.NoError(t, ad.Create(ctx, nil, "orphan"))
+		require.NoError(t, ad.SetTags(ctx, nil, "orphan", map[string]string{
+			config.CloudClusterLabel: "test-cluster", config.CloudNameLabel: "deleted",
+		}))
+		require.NoError(t, ad.Create(ctx, nil, "foreign"))
+		require.NoError(t, ad.SetTags(ctx, nil, "foreign", map[string]string{
+			config.CloudClusterLabel: "other-cluster", config.CloudNameLabel: "deleted",
+		}))
+		require.NoError(t, ad.Create(ctx, nil, "untagged"))
+		collector := &orphanCollector{
+			cl: cl, adapter: ad, endpoint: yosconfig.DefaultEndpoint, clusterID: "test-cluster", clients: rc.clients,
+		}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "orphan", orphans[0].ID)
+		assert.Equal(t, "deleted", orphans[0].Name)
+		assert.Equal(t, "default/sakey", orphans[0].Location)
+	})
+
+	t.Run("orphan is deleted with key it was found with", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		require.NoError(t, ad.Create(ctx, nil, "orphan"))
+		require.NoError(t, ad.SetTags(ctx, nil, "orphan", map[string]string{
+			config.CloudClusterLabel: "test-cluster", config.CloudNameLabel: "deleted",
+		}))
+		collector := &orphanCollector{
+			cl:        cl,
+			adapter:   ad,
+			endpoint:  yosconfig.DefaultEndpoint,
+			clusterID: "test-cluster",
+			keys:      []types.NamespacedName{{Namespace: "default", Name: "sakey"}},
+			clients:   rc.clients,
+		}
+
+		// Act
+		orphans, err := collector.Orphans(ctx)
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		require.NoError(t, collector.Delete(ctx, orphans[0]))
+		lst, err := ad.List(ctx, nil)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Empty(t, lst)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// resolveReferences waits for StaticAccessKey referenced by the object to become ready and to be granted to it.
+func (r *yandexObjectStorageReconciler) resolveReferences(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexObjectStorage,
+) (err error) {
+	if object.Spec.SAKeyRef == nil {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "resolve-references")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	_, err = reference.Resolve(ctx, r.Client, object.Namespace, object.Spec.SAKeyRef, sakeyutils.ReferenceKind)
+	if err == nil {
+		err = sakeyutils.CheckGrant(
+			ctx, r.Client, yosconfig.LongName, object.Namespace, object.Spec.SAKeyRef.NamespacedName(object.Namespace),
+		)
+	}
+	return phase.SyncReferencesResolved(ctx, log, object, &object.Status.Conditions, r.Status().Update, err)
+}
+
+// objectsOfStaticAccessKey maps StaticAccessKey to buckets referring to it, so
+// that they are provisioned as soon as the key is ready.
+func (r *yandexObjectStorageReconciler) objectsOfStaticAccessKey(obj client.Object) []reconcile.Request {
+	return r.mapObjects(obj, func(object *connectorsv1.YandexObjectStorage) bool {
+		ref := object.Spec.SAKeyRef
+		return ref != nil && ref.Matches(object.Namespace, sakeyutils.ReferenceKind.Name, obj)
+	})
+}
+
+// objectsOfStaticAccessKeyGrant maps StaticAccessKeyGrant to buckets using keys of its
+// namespace from other namespaces, so that they are provisioned as soon as the key is granted.
+func (r *yandexObjectStorageReconciler) objectsOfStaticAccessKeyGrant(obj client.Object) []reconcile.Request {
+	return r.mapObjects(obj, func(object *connectorsv1.YandexObjectStorage) bool {
+		key := awsutils.StaticAccessKeyName(
+			object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+		)
+		return key.Namespace == obj.GetNamespace() && object.Namespace != obj.GetNamespace()
+	})
+}
+
+func (r *yandexObjectStorageReconciler) mapObjects(
+	obj client.Object, matches func(object *connectorsv1.YandexObjectStorage) bool,
+) []reconcile.Request {
+	var objects connectorsv1.YandexObjectStorageList
+	if err := r.Client.List(context.Background(), &objects); err != nil {
+		r.log.Error(err, "unable to list buckets", "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var res []reconcile.Request
+	for i := range objects.Items {
+		if object := &objects.Items[i]; matches(object) {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: object.Namespace, Name: object.Name},
+			})
+		}
+	}
+	return res
+}
@@ -11,11 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	v12 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller/adapter"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
 	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
 	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
 )
@@ -35,6 +38,11 @@ func setup(t *testing.T) (
 		cl,
 		ad,
 		log,
+		yosconfig.DefaultEndpoint,
+		"test-cluster",
+		record.NewFakeRecorder(10),
+		awsutils.NewCredentialsCache(),
+		awsutils.NewClientCache(),
 	}
 }
 
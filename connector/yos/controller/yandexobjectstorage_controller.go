@@ -7,12 +7,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
 	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller/adapter"
 	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
@@ -20,34 +26,56 @@ import (
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 // yandexObjectStorageReconciler reconciles a YandexContainerRegistry object
 type yandexObjectStorageReconciler struct {
 	client.Client
-	adapter adapter.YandexObjectStorageAdapter
-	log     logr.Logger
+	adapter   adapter.YandexObjectStorageAdapter
+	log       logr.Logger
+	endpoint  string
+	clusterID string
+	recorder  record.EventRecorder
+
+	// credentials keeps last retrieved credentials of objects for their cleanup
+	credentials *awsutils.CredentialsCache
+	// clients are shared with other connectors and webhooks
+	clients *awsutils.ClientCache
 }
 
 func NewYandexObjectStorageReconciler(
-	cl client.Client, log logr.Logger,
+	cl client.Client,
+	log logr.Logger,
+	endpoint string,
+	clusterID string,
+	recorder record.EventRecorder,
+	clients *awsutils.ClientCache,
 ) (*yandexObjectStorageReconciler, error) {
 	impl, err := adapter.NewYandexObjectStorageAdapterSDK()
 	if err != nil {
 		return nil, err
 	}
 	return &yandexObjectStorageReconciler{
-		Client:  cl,
-		adapter: impl,
-		log:     log,
+		Client:    cl,
+		adapter:   impl,
+		log:       log,
+		endpoint:  endpoint,
+		clusterID: clusterID,
+		recorder:  recorder,
+
+		credentials: awsutils.NewCredentialsCache(),
+		clients:     clients,
 	}, nil
 }
 
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexobjectstorages,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexobjectstorages/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexobjectstorages/finalizers,verbs=update
-// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=get
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=staticaccesskeygrants,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *yandexObjectStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("name", req.NamespacedName)
@@ -67,31 +95,60 @@ func (r *yandexObjectStorageReconciler) Reconcile(ctx context.Context, req ctrl.
 		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
 	}
 
-	cred, err := awsutils.CredentialsFromStaticAccessKey(ctx, object.Namespace, object.Spec.SAKeyName, r.Client)
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Status().Update)
 	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to retrieve credentials: %w", err))
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
 	}
-	sdk, err := yosutils.NewS3Client(ctx, cred)
-	if err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to build sdk: %w", err))
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
 	}
 
-	// If object must be currently finalized, do it and quit
+	// If object must be currently finalized, do it and quit. Credentials are retrieved during
+	// finalization only if they are needed, so that orphaned objects can be deleted without them.
 	if phase.MustBeFinalized(&object.ObjectMeta, yosconfig.FinalizerName) {
-		if err := r.finalize(ctx, log.WithName("finalize"), &object, sdk); err != nil {
-			return config.GetErroredResult(fmt.Errorf("unable to finalize object: %w", err))
+		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Status().Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
 		}
 		return config.GetNormalResult()
 	}
 
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Status().Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *yandexObjectStorageReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexObjectStorage,
+) error {
+	if err := r.resolveReferences(ctx, log.WithName("resolve-references"), object); err != nil {
+		return fmt.Errorf("unable to resolve references: %w", err)
+	}
+
+	sdk, err := r.newSDK(ctx, object)
+	if err != nil {
+		return err
+	}
+
 	if err := phase.RegisterFinalizer(
-		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, &object, yosconfig.FinalizerName,
+		ctx, r.Client, log.WithName("register-finalizer"), &object.ObjectMeta, object, yosconfig.FinalizerName,
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to register finalizer: %w", err))
+		return fmt.Errorf("unable to register finalizer: %w", err)
 	}
 
-	if err := r.allocateResource(ctx, log.WithName("allocate-resource"), &object, sdk); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to allocate resource: %w", err))
+	if err := r.allocateResource(ctx, log.WithName("allocate-resource"), object, sdk); err != nil {
+		return fmt.Errorf("unable to allocate resource: %w", err)
 	}
 
 	if err := phase.ProvideConfigmap(
@@ -101,19 +158,20 @@ func (r *yandexObjectStorageReconciler) Reconcile(ctx context.Context, req ctrl.
 		object.Name, yosconfig.ShortName, object.Namespace,
 		map[string]string{"name": object.Spec.Name},
 	); err != nil {
-		return config.GetErroredResult(fmt.Errorf("unable to provide configmap: %w", err))
+		return fmt.Errorf("unable to provide configmap: %w", err)
 	}
 
-	log.V(1).Info("finished reconciliation")
-	return config.GetNormalResult()
+	return nil
 }
 
 func (r *yandexObjectStorageReconciler) finalize(
 	ctx context.Context,
 	log logr.Logger,
 	object *connectorsv1.YandexObjectStorage,
-	sdk *s3.S3,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if err := phase.RemoveConfigmap(
@@ -125,7 +183,13 @@ func (r *yandexObjectStorageReconciler) finalize(
 		return fmt.Errorf("unable to remove configmap: %w", err)
 	}
 
-	if err := r.deallocateResource(ctx, log.WithName("deallocate-resource"), object, sdk); err != nil {
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		sdk, err := r.cleanupSDK(ctx, log, object)
+		if err != nil {
+			return err
+		}
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object, sdk)
+	}); err != nil {
 		return fmt.Errorf("unable to deallocate resource: %w", err)
 	}
 
@@ -134,14 +198,75 @@ func (r *yandexObjectStorageReconciler) finalize(
 	); err != nil {
 		return fmt.Errorf("unable to deregister finalizer: %w", err)
 	}
+	r.credentials.Forget(object.UID)
 
 	log.Info("successful")
 	return nil
 }
 
+func (r *yandexObjectStorageReconciler) newSDK(ctx context.Context, object *connectorsv1.YandexObjectStorage) (*s3.S3, error) {
+	cred, err := r.retrieveCredentials(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildSDK(ctx, cred)
+}
+
+// cleanupSDK builds sdk for cleanup of cloud resource. If credentials of the object are gone, e.g. because
+// its key was deleted first during namespace deletion, last retrieved credentials are used instead.
+// Without them cleanup is impossible and the object is finalized as orphaned.
+func (r *yandexObjectStorageReconciler) cleanupSDK(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexObjectStorage,
+) (*s3.S3, error) {
+	cred, err := r.retrieveCredentials(ctx, object)
+	if err != nil {
+		if !awsutils.CheckCredentialsGone(err) {
+			return nil, err
+		}
+		cached, ok := r.credentials.Get(object.UID)
+		if !ok {
+			return nil, phase.CleanupImpossible(err)
+		}
+		log.Info("credentials are gone, last retrieved ones are used for cleanup", "reason", err.Error())
+		cred = cached
+	}
+	return r.buildSDK(ctx, cred)
+}
+
+func (r *yandexObjectStorageReconciler) retrieveCredentials(
+	ctx context.Context, object *connectorsv1.YandexObjectStorage,
+) (*credentials.Credentials, error) {
+	key := awsutils.StaticAccessKeyName(
+		object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+	)
+	cred, err := r.clients.GrantedCredentials(ctx, yosconfig.LongName, object.Namespace, key, r.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+	r.credentials.Put(object.UID, cred)
+	return cred, nil
+}
+
+func (r *yandexObjectStorageReconciler) buildSDK(ctx context.Context, cred *credentials.Credentials) (*s3.S3, error) {
+	sdk, err := yosutils.SharedS3Client(ctx, r.clients, r.endpoint, cred)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build sdk: %w", err)
+	}
+	return sdk, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
-func (r *yandexObjectStorageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *yandexObjectStorageReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&connectorsv1.YandexObjectStorage{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &sakey.StaticAccessKey{}},
+			handler.EnqueueRequestsFromMapFunc(r.objectsOfStaticAccessKey),
+		).
+		Watches(
+			&source.Kind{Type: &sakey.StaticAccessKeyGrant{}},
+			handler.EnqueueRequestsFromMapFunc(r.objectsOfStaticAccessKeyGrant),
+		).
+		WithOptions(opts).
+		Complete(tracing.Reconciler(yosconfig.LongName, r))
 }
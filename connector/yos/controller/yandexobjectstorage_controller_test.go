@@ -0,0 +1,207 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+)
+
+func TestFinalize(t *testing.T) {
+	t.Run("finalize with gone credentials orphans resource", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, _, rc := setup(t)
+		obj := createObject("bucket", "missing-sakey", "", "obj", "default")
+		obj.Finalizers = []string{yosconfig.FinalizerName}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		require.NoError(t, rc.finalize(ctx, log, &obj))
+
+		// Assert
+		assert.NotContains(t, obj.Finalizers, yosconfig.FinalizerName)
+		assert.Len(t, rc.recorder.(*record.FakeRecorder).Events, 1)
+	})
+
+	t.Run("finalize with gone credentials uses last retrieved ones", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, ad, rc := setup(t)
+		createSAKeyRequireNoError(ctx, t, cl, "sakey", "default")
+		obj := createObject("bucket", "sakey", "", "obj", "default")
+		obj.UID = "obj-uid"
+		require.NoError(t, cl.Create(ctx, &obj))
+		require.NoError(t, rc.provision(ctx, log, &obj))
+		require.NoError(t, cl.Delete(ctx, &sakey.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "sakey", Namespace: "default"},
+		}))
+
+		// Act
+		require.NoError(t, rc.finalize(ctx, log, &obj))
+		lst, err := ad.List(ctx, nil)
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotContains(t, obj.Finalizers, yosconfig.FinalizerName)
+		assert.Empty(t, lst)
+		assert.Empty(t, rc.recorder.(*record.FakeRecorder).Events)
+	})
+
+	t.Run("finalize of orphaned object does not need credentials", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, _, rc := setup(t)
+		obj := createObject("bucket", "missing-sakey", "", "obj", "default")
+		obj.Finalizers = []string{yosconfig.FinalizerName}
+		obj.Annotations = map[string]string{config.OrphanAnnotation: "true"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		require.NoError(t, rc.finalize(ctx, log, &obj))
+
+		// Assert
+		assert.NotContains(t, obj.Finalizers, yosconfig.FinalizerName)
+	})
+
+	t.Run("finalize of force-deleted object tolerates missing credentials", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, _, rc := setup(t)
+		obj := createObject("bucket", "missing-sakey", "", "obj", "default")
+		obj.Finalizers = []string{yosconfig.FinalizerName}
+		obj.Annotations = map[string]string{config.ForceDeleteAnnotation: "true"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		require.NoError(t, rc.finalize(ctx, log, &obj))
+
+		// Assert
+		assert.NotContains(t, obj.Finalizers, yosconfig.FinalizerName)
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("paused object is not finalized", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, _, rc := setup(t)
+		now := metav1.Now()
+		obj := createObject("bucket", "missing-sakey", "", "obj", "default")
+		obj.Finalizers = []string{yosconfig.FinalizerName}
+		obj.DeletionTimestamp = &now
+		obj.Annotations = map[string]string{config.PausedAnnotation: "true"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		_, err := rc.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "obj"}})
+		require.NoError(t, err)
+		var res connectorsv1.YandexObjectStorage
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "obj"}, &res))
+
+		// Assert
+		assert.Contains(t, res.Finalizers, yosconfig.FinalizerName)
+		assert.True(t, meta.IsStatusConditionTrue(res.Status.Conditions, phase.ConditionPaused))
+	})
+	t.Run("object referring to not ready key waits for it", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, _, rc := setup(t)
+		key := sakey.StaticAccessKey{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey"}}
+		require.NoError(t, cl.Create(ctx, &key))
+		obj := createObject("bucket", "", "", "obj", "default")
+		obj.Spec.SAKeyRef = &reference.ObjectReference{Kind: "StaticAccessKey", Name: "sakey"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		_, err := rc.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "obj"}})
+		var res connectorsv1.YandexObjectStorage
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "obj"}, &res))
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotContains(t, res.Finalizers, yosconfig.FinalizerName)
+		condition := meta.FindStatusCondition(res.Status.Conditions, phase.ConditionReferencesResolved)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Contains(t, condition.Message, "StaticAccessKey default/sakey is not ready")
+		assert.False(t, meta.IsStatusConditionTrue(res.Status.Conditions, phase.ConditionReady))
+	})
+
+	t.Run("object referring to ready key of another namespace waits for grant", func(t *testing.T) {
+		// Arrange
+		ctx, log, cl, _, rc := setup(t)
+		key := sakey.StaticAccessKey{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "sakey"},
+			Status:     sakey.StaticAccessKeyStatus{KeyID: "key-id"},
+		}
+		meta.SetStatusCondition(&key.Status.Conditions, metav1.Condition{
+			Type: phase.ConditionReady, Status: metav1.ConditionTrue, Reason: "Reconciled",
+		})
+		require.NoError(t, cl.Create(ctx, &key))
+		obj := createObject("bucket", "", "", "obj", "default")
+		obj.Spec.SAKeyRef = &reference.ObjectReference{Kind: "StaticAccessKey", Name: "sakey", Namespace: "platform"}
+		require.NoError(t, cl.Create(ctx, &obj))
+
+		// Act
+		before := rc.resolveReferences(ctx, log, &obj)
+		require.NoError(t, cl.Create(ctx, &sakey.StaticAccessKeyGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "grant"},
+			Spec: sakey.StaticAccessKeyGrantSpec{
+				From: []sakey.StaticAccessKeyGrantFrom{{Namespace: "default", Kind: "YandexObjectStorage"}},
+			},
+		}))
+		after := rc.resolveReferences(ctx, log, &obj)
+
+		// Assert
+		require.Error(t, before)
+		assert.Contains(t, before.Error(), "is not granted")
+		assert.NoError(t, after)
+		assert.True(t, meta.IsStatusConditionTrue(obj.Status.Conditions, phase.ConditionReferencesResolved))
+	})
+
+	t.Run("grant is mapped to objects of other namespaces using its keys", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, _, rc := setup(t)
+		grant := sakey.StaticAccessKeyGrant{ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "grant"}}
+		named := createObject("bucket", "sakey", "", "named", "default")
+		named.Spec.SAKeyNamespace = "platform"
+		local := createObject("other-bucket", "sakey", "", "local", "platform")
+		require.NoError(t, cl.Create(ctx, &named))
+		require.NoError(t, cl.Create(ctx, &local))
+
+		// Act
+		requests := rc.objectsOfStaticAccessKeyGrant(&grant)
+
+		// Assert
+		require.Len(t, requests, 1)
+		assert.Equal(t, "default/named", requests[0].String())
+	})
+
+	t.Run("key is mapped to objects referring to it", func(t *testing.T) {
+		// Arrange
+		ctx, _, cl, _, rc := setup(t)
+		key := sakey.StaticAccessKey{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sakey"}}
+		referring := createObject("bucket", "", "", "referring", "default")
+		referring.Spec.SAKeyRef = &reference.ObjectReference{Kind: "StaticAccessKey", Name: "sakey"}
+		named := createObject("other-bucket", "sakey", "", "named", "default")
+		require.NoError(t, cl.Create(ctx, &referring))
+		require.NoError(t, cl.Create(ctx, &named))
+
+		// Act
+		requests := rc.objectsOfStaticAccessKey(&key)
+
+		// Assert
+		require.Len(t, requests, 1)
+		assert.Equal(t, "default/referring", requests[0].String())
+	})
+}
@@ -4,9 +4,9 @@
 package config
 
 const (
-	AWSRegion     = "ru-central1"
-	Endpoint      = "storage.yandexcloud.net"
-	FinalizerName = "finalizer.yos.connectors.cloud.yandex.com"
-	LongName      = "YandexObjectStorage"
-	ShortName     = "yos"
+	AWSRegion       = "ru-central1"
+	DefaultEndpoint = "storage.yandexcloud.net"
+	FinalizerName   = "finalizer.yos.connectors.cloud.yandex.com"
+	LongName        = "YandexObjectStorage"
+	ShortName       = "yos"
 )
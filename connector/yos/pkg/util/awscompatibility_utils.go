@@ -14,16 +14,18 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	"github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
-func NewS3Client(_ context.Context, cred *credentials.Credentials) (*s3.S3, error) {
+func NewS3Client(_ context.Context, endpoint string, cred *credentials.Credentials) (*s3.S3, error) {
 	ses, err := session.NewSession(
 		&aws.Config{
 			Credentials: cred,
-			Endpoint:    aws.String(config.Endpoint),
+			Endpoint:    aws.String(endpoint),
 			EndpointResolver: endpoints.ResolverFunc(
 				func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-					return endpoints.ResolvedEndpoint{URL: config.Endpoint}, nil
+					return endpoints.ResolvedEndpoint{URL: endpoint}, nil
 				},
 			),
 			Region:           aws.String(config.AWSRegion),
@@ -35,5 +37,19 @@ func NewS3Client(_ context.Context, cred *credentials.Credentials) (*s3.S3, erro
 		return nil, fmt.Errorf("unable to get yos sdk: %w", err)
 	}
 
+	tracing.InstrumentAWS(&ses.Handlers)
 	return s3.New(ses), nil
 }
+
+// SharedS3Client returns S3 client built with the credentials, which is shared through the cache.
+func SharedS3Client(
+	ctx context.Context, clients *awsutils.ClientCache, endpoint string, cred *credentials.Credentials,
+) (*s3.S3, error) {
+	sdk, err := clients.Client(cred, endpoint, func() (interface{}, error) {
+		return NewS3Client(ctx, endpoint, cred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.(*s3.S3), nil
+}
@@ -8,8 +8,13 @@ import (
 	"fmt"
 
 	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
 	yosutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/util"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-logr/logr"
@@ -25,29 +30,46 @@ import (
 // +kubebuilder:webhook:path=/validate-connectors-cloud-yandex-com-v1-yandexobjectstorage,mutating=false,failurePolicy=fail,sideEffects=None,groups=connectors.cloud.yandex.com,resources=yandexobjectstorages,verbs=create;update;delete,versions=v1,name=vyandexobjectstorage.yandex.com,admissionReviewVersions=v1
 
 type YOSValidator struct {
-	cl client.Client
+	cl       client.Client
+	endpoint string
+	clients  *awsutils.ClientCache
 }
 
-func NewYOSValidator(cl client.Client) (webhook.Validator, error) {
-	return &YOSValidator{cl: cl}, nil
+func NewYOSValidator(cl client.Client, endpoint string, clients *awsutils.ClientCache) (webhook.Validator, error) {
+	return &YOSValidator{cl: cl, endpoint: endpoint, clients: clients}, nil
 }
 
 func (r *YOSValidator) ValidateCreation(ctx context.Context, log logr.Logger, obj runtime.Object) error {
 	casted := obj.(*v1.YandexObjectStorage)
 	log.Info("validate create", "name", util.NamespacedName(casted))
 
+	if (casted.Spec.SAKeyName == "") == (casted.Spec.SAKeyRef == nil) {
+		return webhook.NewValidationErrorf("exactly one of SAKeyName and SAKeyRef must be set")
+	}
+	// Referenced StaticAccessKey may be created and granted later, resource waits for it
+	if ref := casted.Spec.SAKeyRef; ref != nil {
+		if casted.Spec.SAKeyNamespace != "" {
+			return webhook.NewValidationErrorf("SAKeyNamespace must not be set with SAKeyRef, set namespace of SAKeyRef")
+		}
+		if err := ref.Validate(sakeyconfig.LongName); err != nil {
+			return webhook.NewValidationError(err)
+		}
+		return nil
+	}
+
+	name := awsutils.StaticAccessKeyName(casted.Namespace, casted.Spec.SAKeyNamespace, casted.Spec.SAKeyName, nil)
+	if err := sakeyutils.CheckGrant(ctx, r.cl, yosconfig.LongName, casted.Namespace, name); err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotGranted) {
+			return webhook.NewValidationError(err)
+		}
+		return err
+	}
+
 	var key sakey.StaticAccessKey
-	if err := r.cl.Get(
-		ctx,
-		client.ObjectKey{
-			Name:      casted.Spec.SAKeyName,
-			Namespace: casted.Namespace,
-		},
-		&key,
-	); err != nil {
+	if err := r.cl.Get(ctx, name, &key); err != nil {
 		if errors.IsNotFound(err) {
 			return webhook.NewValidationErrorf(
-				"static access key \"%s\" not found in the %s namespace", casted.Spec.SAKeyName, casted.Namespace,
+				"static access key \"%s\" not found in the %s namespace", name.Name, name.Namespace,
 			)
 		}
 		return fmt.Errorf("unable to get specified static access key: %w", err)
@@ -75,11 +97,24 @@ func (r *YOSValidator) ValidateDeletion(ctx context.Context, log logr.Logger, ob
 	casted := obj.(*v1.YandexObjectStorage)
 	log.Info("validate delete", "name", util.NamespacedName(casted))
 
-	cred, err := awsutils.CredentialsFromStaticAccessKey(ctx, casted.Namespace, casted.Spec.SAKeyName, r.cl)
+	// Orphaned bucket is left as is, force-deleted one may stay when it cannot be cleaned up
+	if phase.CleanupBypassed(casted) {
+		return nil
+	}
+
+	key := awsutils.StaticAccessKeyName(
+		casted.Namespace, casted.Spec.SAKeyNamespace, casted.Spec.SAKeyName, casted.Spec.SAKeyRef,
+	)
+	cred, err := r.clients.GrantedCredentials(ctx, yosconfig.LongName, casted.Namespace, key, r.cl)
 	if err != nil {
+		// Emptiness of the bucket cannot be checked anymore, the connector decides whether it can be cleaned up
+		if awsutils.CheckCredentialsGone(err) {
+			log.Info("credentials are gone, emptiness of bucket is not checked", "reason", err.Error())
+			return nil
+		}
 		return fmt.Errorf("unable to retrieve credentials: %w", err)
 	}
-	sdk, err := yosutils.NewS3Client(ctx, cred)
+	sdk, err := yosutils.SharedS3Client(ctx, r.clients, r.endpoint, cred)
 	if err != nil {
 		return fmt.Errorf("unable to build s3 sdk: %w", err)
 	}
@@ -92,6 +127,10 @@ func (r *YOSValidator) ValidateDeletion(ctx context.Context, log logr.Logger, ob
 		},
 	)
 	if err != nil {
+		// Bucket that is already gone has nothing to protect
+		if awsutils.CheckS3DoesNotExist(err) {
+			return nil
+		}
 		return fmt.Errorf("unable to list objects in s3: %w", err)
 	}
 
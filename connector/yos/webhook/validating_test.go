@@ -6,6 +6,8 @@ package webhook
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
@@ -14,10 +16,14 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
 	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
 )
@@ -25,7 +31,7 @@ import (
 func setupValidation(t *testing.T) (context.Context, webhook.Validator, logr.Logger, client.Client) {
 	t.Helper()
 	cl := k8sfake.NewFakeClient()
-	return context.TODO(), &YOSValidator{cl: cl}, logrfake.NewFakeLogger(t), cl
+	return context.TODO(), &YOSValidator{cl: cl, clients: awsutils.NewClientCache()}, logrfake.NewFakeLogger(t), cl
 }
 
 func createSAKey(ctx context.Context, t *testing.T, cl client.Client, name, namespace string) {
@@ -42,6 +48,24 @@ func createSAKey(ctx context.Context, t *testing.T, cl client.Client, name, name
 	)
 }
 
+func createGrant(ctx context.Context, t *testing.T, cl client.Client, namespace, keyName, from, kind string) {
+	t.Helper()
+	require.NoError(
+		t, cl.Create(
+			ctx, &sakey.StaticAccessKeyGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "grant",
+					Namespace: namespace,
+				},
+				Spec: sakey.StaticAccessKeyGrantSpec{
+					KeyNames: []string{keyName},
+					From:     []sakey.StaticAccessKeyGrantFrom{{Namespace: from, Kind: kind}},
+				},
+			},
+		),
+	)
+}
+
 func TestCreateValidation(t *testing.T) {
 	t.Run("create on an existent SAKey is valid", func(t *testing.T) {
 		// Arrange
@@ -128,6 +152,73 @@ func TestCreateValidation(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
 	})
+
+	t.Run("create on reference to not yet created SAKey is valid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1.YandexObjectStorageSpec{
+				Name:     "bucket",
+				SAKeyRef: &reference.ObjectReference{Kind: "StaticAccessKey", Name: "real-sakey"},
+			},
+		}
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("create on SAKey of another namespace without grant is invalid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, cl := setupValidation(t)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1.YandexObjectStorageSpec{
+				Name:           "bucket",
+				SAKeyName:      "real-sakey",
+				SAKeyNamespace: "platform",
+			},
+		}
+		createSAKey(ctx, t, cl, "real-sakey", "platform")
+		createGrant(ctx, t, cl, "platform", "real-sakey", "other-namespace", "")
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	t.Run("create on SAKey of another namespace with grant is valid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, cl := setupValidation(t)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1.YandexObjectStorageSpec{
+				Name:           "bucket",
+				SAKeyName:      "real-sakey",
+				SAKeyNamespace: "platform",
+			},
+		}
+		createSAKey(ctx, t, cl, "real-sakey", "platform")
+		createGrant(ctx, t, cl, "platform", "real-sakey", "some-namespace", "YandexObjectStorage")
+
+		// Act
+		err := wh.ValidateCreation(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
 }
 
 func TestUpdateValidation(t *testing.T) {
@@ -179,6 +270,140 @@ func TestUpdateValidation(t *testing.T) {
 	})
 }
 
+// setupS3 makes validator list buckets with stub S3 that answers every request with the status and body.
+func setupS3(ctx context.Context, t *testing.T, wh webhook.Validator, status int, body string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	validator := wh.(*YOSValidator)
+	validator.endpoint = server.URL
+	require.NoError(t, validator.cl.Create(ctx, &sakey.StaticAccessKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "sakey", Namespace: "default"},
+		Status:     sakey.StaticAccessKeyStatus{SecretName: "sakey-secret"},
+	}))
+	require.NoError(t, validator.cl.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sakey-secret", Namespace: "default"},
+		Data:       map[string][]byte{"key": []byte("key"), "secret": []byte("secret")},
+	}))
+}
+
+const (
+	nonEmptyBucket = `<ListBucketResult><Name>bucket</Name><Contents><Key>object</Key></Contents></ListBucketResult>`
+	noSuchBucket   = `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`
+	accessDenied   = `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`
+)
+
 func TestDeleteValidate(t *testing.T) {
-	// TODO: test with aws sdk mock
+	t.Run("delete with gone SAKey is valid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "obj", Namespace: "default"},
+			Spec:       v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "missing-sakey"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete of non-empty bucket is invalid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		setupS3(ctx, t, wh, http.StatusOK, nonEmptyBucket)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "obj", Namespace: "default"},
+			Spec:       v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	for _, annotation := range []string{config.OrphanAnnotation, config.ForceDeleteAnnotation} {
+		annotation := annotation
+		t.Run("delete of non-empty bucket with "+annotation+" is valid", func(t *testing.T) {
+			// Arrange
+			ctx, wh, log, _ := setupValidation(t)
+			setupS3(ctx, t, wh, http.StatusOK, nonEmptyBucket)
+			obj := v1.YandexObjectStorage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "obj",
+					Namespace:   "default",
+					Annotations: map[string]string{annotation: "true"},
+				},
+				Spec: v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+			}
+
+			// Act
+			err := wh.ValidateDeletion(ctx, log, &obj)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("delete of gone bucket is valid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		setupS3(ctx, t, wh, http.StatusNotFound, noSuchBucket)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "obj", Namespace: "default"},
+			Spec:       v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete with failed listing is refused", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		setupS3(ctx, t, wh, http.StatusForbidden, accessDenied)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "obj", Namespace: "default"},
+			Spec:       v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	t.Run("delete with failed listing and "+config.ForceDeleteAnnotation+" is valid", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log, _ := setupValidation(t)
+		setupS3(ctx, t, wh, http.StatusForbidden, accessDenied)
+		obj := v1.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "obj",
+				Namespace:   "default",
+				Annotations: map[string]string{config.ForceDeleteAnnotation: "true"},
+			},
+			Spec: v1.YandexObjectStorageSpec{Name: "bucket", SAKeyName: "sakey"},
+		}
+
+		// Act
+		err := wh.ValidateDeletion(ctx, log, &obj)
+
+		// Assert
+		assert.NoError(t, err)
+	})
 }
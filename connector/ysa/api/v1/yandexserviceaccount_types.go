@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// YandexServiceAccountSpec defines the desired state of YandexServiceAccount
+type YandexServiceAccountSpec struct {
+	// Name: name of service account, it must be unique within the cloud
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// FolderID: id of a folder in which service account is located. Must be immutable.
+	// +kubebuilder:validation:Required
+	FolderID string `json:"folderId"`
+
+	// Roles: roles of service account in its folder, e.g. storage.editor.
+	// Roles granted to the service account in the folder by other means are revoked.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+}
+
+// YandexServiceAccountStatus defines the observed state of YandexServiceAccount
+type YandexServiceAccountStatus struct {
+	// ID: id of service account
+	ID string `json:"id,omitempty"`
+
+	// CreatedAt: RFC3339-formatted string, representing creation time of resource
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// Roles: roles of service account in its folder
+	Roles []string `json:"roles,omitempty"`
+
+	// Conditions: latest observations of the object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// YandexServiceAccount is the Schema for the yandexserviceaccounts API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=ysa
+type YandexServiceAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YandexServiceAccountSpec   `json:"spec,omitempty"`
+	Status YandexServiceAccountStatus `json:"status,omitempty"`
+}
+
+// YandexServiceAccountList contains a list of YandexServiceAccount
+// +kubebuilder:object:root=true
+type YandexServiceAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YandexServiceAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YandexServiceAccount{}, &YandexServiceAccountList{})
+}
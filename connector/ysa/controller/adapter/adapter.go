@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+type YandexServiceAccountAdapterSDK struct {
+	sdk *ycsdk.SDK
+}
+
+func NewYandexServiceAccountAdapterSDK(sdk *ycsdk.SDK) YandexServiceAccountAdapter {
+	return YandexServiceAccountAdapterSDK{
+		sdk: sdk,
+	}
+}
+
+func (r YandexServiceAccountAdapterSDK) Create(
+	ctx context.Context, request *iam.CreateServiceAccountRequest,
+) (*iam.ServiceAccount, error) {
+	op, err := r.sdk.WrapOperation(r.sdk.IAM().ServiceAccount().Create(ctx, request))
+	if err != nil {
+		return nil, errorhandling.NewCloudError(err, "CreateServiceAccount", request.Name)
+	}
+
+	if err := op.Wait(ctx); err != nil {
+		return nil, errorhandling.NewCloudError(err, "CreateServiceAccount", request.Name)
+	}
+
+	res, err := op.Response()
+	if err != nil {
+		return nil, errorhandling.NewCloudError(err, "CreateServiceAccount", request.Name)
+	}
+
+	return res.(*iam.ServiceAccount), nil
+}
+
+func (r YandexServiceAccountAdapterSDK) Read(ctx context.Context, saID string) (*iam.ServiceAccount, error) {
+	res, err := r.sdk.IAM().ServiceAccount().Get(
+		ctx, &iam.GetServiceAccountRequest{
+			ServiceAccountId: saID,
+		},
+	)
+	return res, errorhandling.NewCloudError(err, "GetServiceAccount", saID)
+}
+
+func (r YandexServiceAccountAdapterSDK) List(ctx context.Context, folderID string) ([]*iam.ServiceAccount, error) {
+	var res []*iam.ServiceAccount
+	it := r.sdk.IAM().ServiceAccount().ServiceAccountIterator(ctx, &iam.ListServiceAccountsRequest{FolderId: folderID})
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListServiceAccounts", folderID)
+	}
+	return res, nil
+}
+
+func (r YandexServiceAccountAdapterSDK) Update(ctx context.Context, request *iam.UpdateServiceAccountRequest) error {
+	op, err := r.sdk.WrapOperation(r.sdk.IAM().ServiceAccount().Update(ctx, request))
+	if err != nil {
+		return errorhandling.NewCloudError(err, "UpdateServiceAccount", request.ServiceAccountId)
+	}
+	if err := op.Wait(ctx); err != nil {
+		return errorhandling.NewCloudError(err, "UpdateServiceAccount", request.ServiceAccountId)
+	}
+	return nil
+}
+
+func (r YandexServiceAccountAdapterSDK) Delete(ctx context.Context, saID string) error {
+	op, err := r.sdk.WrapOperation(
+		r.sdk.IAM().ServiceAccount().Delete(
+			ctx, &iam.DeleteServiceAccountRequest{
+				ServiceAccountId: saID,
+			},
+		),
+	)
+	if err != nil {
+		return errorhandling.NewCloudError(err, "DeleteServiceAccount", saID)
+	}
+	if err := op.Wait(ctx); err != nil {
+		return errorhandling.NewCloudError(err, "DeleteServiceAccount", saID)
+	}
+	return nil
+}
+
+func (r YandexServiceAccountAdapterSDK) ListRoles(ctx context.Context, folderID, saID string) ([]string, error) {
+	var res []string
+	it := r.sdk.ResourceManager().Folder().FolderAccessBindingsIterator(
+		ctx, &access.ListAccessBindingsRequest{ResourceId: folderID},
+	)
+	for it.Next() {
+		binding := it.Value()
+		if binding.Subject.GetType() == ysaconfig.SubjectType && binding.Subject.GetId() == saID {
+			res = append(res, binding.RoleId)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, errorhandling.NewCloudError(err, "ListFolderAccessBindings", folderID)
+	}
+	return res, nil
+}
+
+func (r YandexServiceAccountAdapterSDK) UpdateRoles(
+	ctx context.Context, folderID, saID string, add, remove []string,
+) error {
+	var deltas []*access.AccessBindingDelta
+	for _, role := range add {
+		deltas = append(deltas, newDelta(access.AccessBindingAction_ADD, role, saID))
+	}
+	for _, role := range remove {
+		deltas = append(deltas, newDelta(access.AccessBindingAction_REMOVE, role, saID))
+	}
+
+	op, err := r.sdk.WrapOperation(
+		r.sdk.ResourceManager().Folder().UpdateAccessBindings(
+			ctx, &access.UpdateAccessBindingsRequest{
+				ResourceId:          folderID,
+				AccessBindingDeltas: deltas,
+			},
+		),
+	)
+	if err != nil {
+		return errorhandling.NewCloudError(err, "UpdateFolderAccessBindings", folderID)
+	}
+	if err := op.Wait(ctx); err != nil {
+		return errorhandling.NewCloudError(err, "UpdateFolderAccessBindings", folderID)
+	}
+	return nil
+}
+
+func newDelta(action access.AccessBindingAction, role, saID string) *access.AccessBindingDelta {
+	return &access.AccessBindingDelta{
+		Action: action,
+		AccessBinding: &access.AccessBinding{
+			RoleId:  role,
+			Subject: &access.Subject{Id: saID, Type: ysaconfig.SubjectType},
+		},
+	}
+}
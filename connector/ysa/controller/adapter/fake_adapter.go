@@ -0,0 +1,118 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+type FakeYandexServiceAccountAdapter struct {
+	Storage map[string]*iam.ServiceAccount
+	// Roles maps folder id to service account id to its roles in the folder
+	Roles  map[string]map[string][]string
+	FreeID int
+}
+
+func NewFakeYandexServiceAccountAdapter() FakeYandexServiceAccountAdapter {
+	return FakeYandexServiceAccountAdapter{
+		Storage: map[string]*iam.ServiceAccount{},
+		Roles:   map[string]map[string][]string{},
+		FreeID:  0,
+	}
+}
+
+func (r *FakeYandexServiceAccountAdapter) Create(
+	_ context.Context, request *iam.CreateServiceAccountRequest,
+) (*iam.ServiceAccount, error) {
+	for _, sa := range r.Storage {
+		if sa.Name == request.Name {
+			return nil, status.Errorf(codes.AlreadyExists, "service account already exists: "+request.Name)
+		}
+	}
+	sa := iam.ServiceAccount{
+		Id:          strconv.Itoa(r.FreeID),
+		FolderId:    request.FolderId,
+		CreatedAt:   timestamppb.Now(),
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	r.Storage[sa.Id] = &sa
+	r.FreeID++
+	return &sa, nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) Read(_ context.Context, saID string) (*iam.ServiceAccount, error) {
+	if _, ok := r.Storage[saID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "service account not found: "+saID)
+	}
+	return r.Storage[saID], nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) List(_ context.Context, folderID string) ([]*iam.ServiceAccount, error) {
+	var result []*iam.ServiceAccount
+	for _, sa := range r.Storage {
+		if sa.FolderId == folderID {
+			result = append(result, sa)
+		}
+	}
+	return result, nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) Update(_ context.Context, request *iam.UpdateServiceAccountRequest) error {
+	if _, ok := r.Storage[request.ServiceAccountId]; !ok {
+		return status.Errorf(codes.NotFound, "service account not found: "+request.ServiceAccountId)
+	}
+	for _, path := range request.UpdateMask.Paths {
+		if path == "name" {
+			r.Storage[request.ServiceAccountId].Name = request.Name
+		}
+		if path == "description" {
+			r.Storage[request.ServiceAccountId].Description = request.Description
+		}
+	}
+	return nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) Delete(_ context.Context, saID string) error {
+	sa, ok := r.Storage[saID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "service account not found: "+saID)
+	}
+	delete(r.Storage, saID)
+	delete(r.Roles[sa.FolderId], saID)
+	return nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) ListRoles(_ context.Context, folderID, saID string) ([]string, error) {
+	return append([]string{}, r.Roles[folderID][saID]...), nil
+}
+
+func (r *FakeYandexServiceAccountAdapter) UpdateRoles(
+	_ context.Context, folderID, saID string, add, remove []string,
+) error {
+	if _, ok := r.Roles[folderID]; !ok {
+		r.Roles[folderID] = map[string][]string{}
+	}
+	var roles []string
+	for _, role := range r.Roles[folderID][saID] {
+		if !util.ContainsString(remove, role) {
+			roles = append(roles, role)
+		}
+	}
+	for _, role := range add {
+		if !util.ContainsString(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	r.Roles[folderID][saID] = roles
+	return nil
+}
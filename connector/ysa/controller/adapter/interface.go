@@ -0,0 +1,22 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package adapter
+
+import (
+	"context"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+)
+
+type YandexServiceAccountAdapter interface {
+	Create(ctx context.Context, request *iam.CreateServiceAccountRequest) (*iam.ServiceAccount, error)
+	Read(ctx context.Context, saID string) (*iam.ServiceAccount, error)
+	List(ctx context.Context, folderID string) ([]*iam.ServiceAccount, error)
+	Update(ctx context.Context, request *iam.UpdateServiceAccountRequest) error
+	Delete(ctx context.Context, saID string) error
+	// ListRoles returns roles bound to the service account in the folder.
+	ListRoles(ctx context.Context, folderID, saID string) ([]string, error)
+	// UpdateRoles binds and unbinds roles of the service account in the folder.
+	UpdateRoles(ctx context.Context, folderID, saID string, add, remove []string) error
+}
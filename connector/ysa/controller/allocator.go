@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	ysautils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *yandexServiceAccountReconciler) allocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount,
+) (_ *iam.ServiceAccount, err error) {
+	ctx, span := tracing.Start(ctx, "allocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	res, err := ysautils.GetServiceAccount(
+		ctx, object.Status.ID, object.Spec.FolderID, object.Name, r.clusterID, r.adapter,
+	)
+	if err == nil {
+		return res, nil
+	}
+	if !errorhandling.CheckConnectorErrorCode(err, ysaconfig.ErrCodeYSANotFound) {
+		return nil, fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	resp, err := r.adapter.Create(
+		ctx, &iam.CreateServiceAccountRequest{
+			FolderId:    object.Spec.FolderID,
+			Name:        object.Spec.Name,
+			Description: ysaconfig.GetServiceAccountDescription(r.clusterID, object.Name),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create resource: %w", err)
+	}
+	log.Info("successful")
+	return resp, nil
+}
+
+func (r *yandexServiceAccountReconciler) deallocateResource(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deallocate-resource")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	res, err := ysautils.GetServiceAccount(
+		ctx, object.Status.ID, object.Spec.FolderID, object.Name, r.clusterID, r.adapter,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, ysaconfig.ErrCodeYSANotFound) {
+			log.Info("already deleted")
+			return nil
+		}
+		return fmt.Errorf("unable to get resource: %w", err)
+	}
+
+	// Bindings of deleted service account are not removed from the folder right away
+	roles, err := r.adapter.ListRoles(ctx, res.FolderId, res.Id)
+	if err != nil {
+		return fmt.Errorf("unable to list roles: %w", err)
+	}
+	if len(roles) != 0 {
+		if err := r.adapter.UpdateRoles(ctx, res.FolderId, res.Id, nil, roles); err != nil {
+			return fmt.Errorf("unable to revoke roles: %w", err)
+		}
+	}
+
+	if err := r.adapter.Delete(ctx, res.Id); err != nil {
+		return fmt.Errorf("unable to delete resource: %w", err)
+	}
+	log.Info("successful")
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+)
+
+func TestAllocate(t *testing.T) {
+	t.Run(
+		"allocate on empty cloud creates resource", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "folder")
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, lst, 1)
+			assert.Equal(t, "resource", lst[0].Name)
+			assert.Equal(t, ysaconfig.GetServiceAccountDescription("test-cluster", "obj"), lst[0].Description)
+			assert.Equal(t, lst[0].Id, res.Id)
+		},
+	)
+
+	t.Run(
+		"allocate on existing resource returns it", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res1, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+
+			// Act
+			res2, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			lst, err := ad.List(ctx, "folder")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Len(t, lst, 1)
+			assert.Equal(t, res1.Id, res2.Id)
+		},
+	)
+}
+
+func TestDeallocate(t *testing.T) {
+	t.Run(
+		"deallocate revokes roles and deletes resource", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default", "storage.editor")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			_, err = rc.matchRoles(ctx, log, &obj, res)
+			require.NoError(t, err)
+
+			// Act
+			require.NoError(t, rc.deallocateResource(ctx, log, &obj))
+			lst, err := ad.List(ctx, "folder")
+			require.NoError(t, err)
+			roles, err := ad.ListRoles(ctx, "folder", res.Id)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Empty(t, lst)
+			assert.Empty(t, roles)
+		},
+	)
+
+	t.Run(
+		"deallocate on deleted resource does nothing", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, _, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default")
+			require.NoError(t, cl.Create(ctx, &obj))
+
+			// Act
+			err := rc.deallocateResource(ctx, log, &obj)
+
+			// Assert
+			assert.NoError(t, err)
+		},
+	)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller/adapter"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// orphanCollector finds service accounts marked with this cluster id that have no
+// YandexServiceAccount object with the same name in the same folder.
+type orphanCollector struct {
+	cl        client.Reader
+	adapter   adapter.YandexServiceAccountAdapter
+	clusterID string
+	folders   []string
+}
+
+// NewOrphanCollector creates collector that looks through the given folders and
+// folders of all existing YandexServiceAccount objects.
+func NewOrphanCollector(cl client.Reader, sdk *ycsdk.SDK, clusterID string, folders []string) gc.Collector {
+	return &orphanCollector{
+		cl:        cl,
+		adapter:   adapter.NewYandexServiceAccountAdapterSDK(sdk),
+		clusterID: clusterID,
+		folders:   folders,
+	}
+}
+
+func (r *orphanCollector) Kind() string {
+	return ysaconfig.ShortName
+}
+
+func (r *orphanCollector) Orphans(ctx context.Context) ([]gc.Resource, error) {
+	var objects connectorsv1.YandexServiceAccountList
+	if err := r.cl.List(ctx, &objects); err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+
+	folders := append([]string{}, r.folders...)
+	backed := map[string]bool{}
+	for _, object := range objects.Items {
+		if !util.ContainsString(folders, object.Spec.FolderID) {
+			folders = append(folders, object.Spec.FolderID)
+		}
+		backed[object.Spec.FolderID+"/"+object.Name] = true
+	}
+
+	var res []gc.Resource
+	for _, folder := range folders {
+		lst, err := r.adapter.List(ctx, folder)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list service accounts in folder %s: %w", folder, err)
+		}
+		for _, sa := range lst {
+			cluster, name, ok := config.ParseCloudDescription(sa.Description)
+			if !ok || cluster != r.clusterID || backed[folder+"/"+name] {
+				continue
+			}
+			res = append(res, gc.Resource{ID: sa.Id, Name: name, Location: folder})
+		}
+	}
+	return res, nil
+}
+
+func (r *orphanCollector) Delete(ctx context.Context, resource gc.Resource) error {
+	roles, err := r.adapter.ListRoles(ctx, resource.Location, resource.ID)
+	if err != nil {
+		return fmt.Errorf("unable to list roles: %w", err)
+	}
+	if len(roles) != 0 {
+		if err := r.adapter.UpdateRoles(ctx, resource.Location, resource.ID, nil, roles); err != nil {
+			return fmt.Errorf("unable to revoke roles: %w", err)
+		}
+	}
+	return r.adapter.Delete(ctx, resource.ID)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// matchRoles makes roles of the service account in its folder match the spec
+// and returns roles the service account has after that.
+func (r *yandexServiceAccountReconciler) matchRoles(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount, res *iam.ServiceAccount,
+) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "match-roles")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	current, err := r.adapter.ListRoles(ctx, res.FolderId, res.Id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list roles: %w", err)
+	}
+
+	var desired, add, remove []string
+	for _, role := range object.Spec.Roles {
+		if util.ContainsString(desired, role) {
+			continue
+		}
+		desired = append(desired, role)
+		if !util.ContainsString(current, role) {
+			add = append(add, role)
+		}
+	}
+	for _, role := range current {
+		if !util.ContainsString(desired, role) {
+			remove = append(remove, role)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return current, nil
+	}
+
+	if err := r.adapter.UpdateRoles(ctx, res.FolderId, res.Id, add, remove); err != nil {
+		return nil, fmt.Errorf("unable to update roles: %w", err)
+	}
+
+	log.Info("successful", "granted", add, "revoked", remove)
+	return desired, nil
+}
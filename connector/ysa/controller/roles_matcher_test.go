@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRoles(t *testing.T) {
+	t.Run(
+		"match grants missing roles", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default", "storage.editor", "ymq.admin")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+
+			// Act
+			roles, err := rc.matchRoles(ctx, log, &obj, res)
+			require.NoError(t, err)
+			actual, err := ad.ListRoles(ctx, "folder", res.Id)
+			require.NoError(t, err)
+
+			// Assert
+			assert.ElementsMatch(t, []string{"storage.editor", "ymq.admin"}, roles)
+			assert.ElementsMatch(t, []string{"storage.editor", "ymq.admin"}, actual)
+		},
+	)
+
+	t.Run(
+		"match revokes roles missing from spec", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl, ad, rc := setup(t)
+			obj := createObject("resource", "folder", "obj", "default", "storage.editor")
+			require.NoError(t, cl.Create(ctx, &obj))
+			res, err := rc.allocateResource(ctx, log, &obj)
+			require.NoError(t, err)
+			require.NoError(t, ad.UpdateRoles(ctx, "folder", res.Id, []string{"editor", "storage.editor"}, nil))
+
+			// Act
+			roles, err := rc.matchRoles(ctx, log, &obj, res)
+			require.NoError(t, err)
+			actual, err := ad.ListRoles(ctx, "folder", res.Id)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, []string{"storage.editor"}, roles)
+			assert.Equal(t, []string{"storage.editor"}, actual)
+		},
+	)
+}
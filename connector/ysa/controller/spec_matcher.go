@@ -0,0 +1,43 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *yandexServiceAccountReconciler) matchSpec(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount, res *iam.ServiceAccount,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "match-spec")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if res.Name == object.Spec.Name {
+		return nil
+	}
+
+	if err := r.adapter.Update(
+		ctx, &iam.UpdateServiceAccountRequest{
+			ServiceAccountId: res.Id,
+			UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			Name:             object.Spec.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("unable to update resource: %w", err)
+	}
+	res.Name = object.Spec.Name
+
+	log.Info("successful")
+	return nil
+}
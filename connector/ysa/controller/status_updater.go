@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+func (r *yandexServiceAccountReconciler) updateStatus(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount, res *iam.ServiceAccount,
+	roles []string,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "update-status")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	roles = append([]string{}, roles...)
+	sort.Strings(roles)
+	if len(roles) == 0 {
+		roles = nil
+	}
+
+	if object.Status.ID == res.Id &&
+		object.Status.CreatedAt == res.CreatedAt.AsTime().Format(time.RFC3339) &&
+		reflect.DeepEqual(object.Status.Roles, roles) {
+		return nil
+	}
+
+	object.Status.ID = res.Id
+	object.Status.CreatedAt = res.CreatedAt.AsTime().Format(time.RFC3339)
+	object.Status.Roles = roles
+
+	if err := r.Client.Update(ctx, object); err != nil {
+		return fmt.Errorf("unable to update object status: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller/adapter"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+func setup(t *testing.T) (
+	context.Context,
+	logr.Logger,
+	client.Client,
+	adapter.YandexServiceAccountAdapter,
+	yandexServiceAccountReconciler,
+) {
+	t.Helper()
+	ad := adapter.NewFakeYandexServiceAccountAdapter()
+	cl := k8sfake.NewFakeClient()
+	log := logrfake.NewFakeLogger(t)
+	return context.Background(), log, cl, &ad, yandexServiceAccountReconciler{
+		cl,
+		&ad,
+		log,
+		"test-cluster",
+		record.NewFakeRecorder(10),
+	}
+}
+
+func createObject(specName, folderID, metaName, namespace string, roles ...string) connectorsv1.YandexServiceAccount {
+	return connectorsv1.YandexServiceAccount{
+		Spec: connectorsv1.YandexServiceAccountSpec{
+			Name:     specName,
+			FolderID: folderID,
+			Roles:    roles,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metaName,
+			Namespace: namespace,
+		},
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller/adapter"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
+)
+
+// yandexServiceAccountReconciler reconciles a YandexServiceAccount object
+type yandexServiceAccountReconciler struct {
+	client.Client
+	adapter   adapter.YandexServiceAccountAdapter
+	log       logr.Logger
+	clusterID string
+	recorder  record.EventRecorder
+}
+
+func NewYandexServiceAccountReconciler(log logr.Logger, cl client.Client,
+	sdk *ycsdk.SDK, clusterID string, recorder record.EventRecorder) *yandexServiceAccountReconciler {
+	return &yandexServiceAccountReconciler{
+		Client:    cl,
+		adapter:   adapter.NewYandexServiceAccountAdapterSDK(sdk),
+		log:       log,
+		clusterID: clusterID,
+		recorder:  recorder,
+	}
+}
+
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexserviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexserviceaccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=connectors.cloud.yandex.com,resources=yandexserviceaccounts/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *yandexServiceAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("name", req.NamespacedName)
+	log.V(1).Info("started reconciliation")
+
+	// Try to retrieve object from k8s
+	var object connectorsv1.YandexServiceAccount
+	if err := r.Get(ctx, req.NamespacedName, &object); err != nil {
+		// It still can be OK if we have not found it, and we do not need to reconcile it again
+
+		// This outcome signifies that we just cannot find object, that is ok
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("object not found in k8s, reconciliation not possible")
+			return config.GetNeverResult()
+		}
+
+		return config.GetErroredResult(fmt.Errorf("unable to get object from k8s: %w", err))
+	}
+
+	// Paused object is neither finalized nor allocated, only its Paused condition is maintained
+	paused, err := phase.SyncPaused(ctx, log.WithName("sync-paused"), &object, &object.Status.Conditions, r.Update)
+	if err != nil {
+		return config.GetErroredResult(fmt.Errorf("unable to sync paused condition: %w", err))
+	}
+	if paused {
+		log.V(1).Info("reconciliation is paused")
+		return config.GetNormalResult()
+	}
+
+	// If object must be currently finalized, do it and quit
+	if phase.MustBeFinalized(&object.ObjectMeta, ysaconfig.FinalizerName) {
+		if err := r.finalize(ctx, log.WithName("finalize"), &object); err != nil {
+			return config.GetErroredResult(phase.SyncReady(
+				ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update,
+				fmt.Errorf("unable to finalize object: %w", err),
+			))
+		}
+		return config.GetNormalResult()
+	}
+
+	err = r.provision(ctx, log, &object)
+	if err := phase.SyncReady(
+		ctx, log.WithName("sync-ready"), &object, &object.Status.Conditions, r.Update, err,
+	); err != nil {
+		return config.GetErroredResult(err)
+	}
+
+	log.V(1).Info("finished reconciliation")
+	return config.GetNormalResult()
+}
+
+// provision makes cloud resource match the object, its error is reflected in Ready condition.
+func (r *yandexServiceAccountReconciler) provision(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount,
+) error {
+	if err := phase.RegisterFinalizer(
+		ctx, r.Client, log, &object.ObjectMeta, object, ysaconfig.FinalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to register finalizer: %w", err)
+	}
+
+	res, err := r.allocateResource(ctx, log.WithName("allocate-resource"), object)
+	if err != nil {
+		return fmt.Errorf("unable to allocate resource: %w", err)
+	}
+
+	if err := r.matchSpec(ctx, log.WithName("match-spec"), object, res); err != nil {
+		return fmt.Errorf("unable to match spec: %w", err)
+	}
+
+	roles, err := r.matchRoles(ctx, log.WithName("match-roles"), object, res)
+	if err != nil {
+		return fmt.Errorf("unable to match roles: %w", err)
+	}
+
+	if err := r.updateStatus(ctx, log.WithName("update-status"), object, res, roles); err != nil {
+		return fmt.Errorf("unable to update status: %w", err)
+	}
+
+	if err := phase.ProvideConfigmap(
+		ctx,
+		r.Client,
+		log.WithName("provide-configmap"),
+		object.Name, ysaconfig.ShortName, object.Namespace,
+		map[string]string{"ID": object.Status.ID},
+	); err != nil {
+		return fmt.Errorf("unable to provide configmap: %w", err)
+	}
+
+	return nil
+}
+
+func (r *yandexServiceAccountReconciler) finalize(
+	ctx context.Context, log logr.Logger, object *connectorsv1.YandexServiceAccount,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "finalize")
+	defer func() { tracing.End(span, err) }()
+
+	log.V(1).Info("started")
+
+	if err := phase.RemoveConfigmap(
+		ctx,
+		r.Client,
+		log.WithName("remove-configmap"),
+		object.Name, ysaconfig.ShortName, object.Namespace,
+	); err != nil {
+		return fmt.Errorf("unable to remove configmap: %w", err)
+	}
+
+	if err := phase.Deallocate(log, r.recorder, object, func() error {
+		return r.deallocateResource(ctx, log.WithName("deallocate-resource"), object)
+	}); err != nil {
+		return fmt.Errorf("unable to deallocate resource: %w", err)
+	}
+
+	if err := phase.DeregisterFinalizer(
+		ctx, r.Client, log.WithName("deregister-finalizer"), &object.ObjectMeta, object, ysaconfig.FinalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to deregister finalizer: %w", err)
+	}
+
+	log.Info("successful")
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *yandexServiceAccountReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&connectorsv1.YandexServiceAccount{}).
+		WithOptions(opts).
+		Complete(tracing.Reconciler(ysaconfig.LongName, r))
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package config
+
+import (
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+const (
+	FinalizerName = "finalizer.ysa.connectors.cloud.yandex.com"
+	LongName      = "YandexServiceAccount"
+	ShortName     = "ysa"
+
+	ErrCodeYSANotFound = "yc.ysa.not-found"
+
+	// SubjectType is the type of access binding subject for service accounts
+	SubjectType = "serviceAccount"
+)
+
+// GetServiceAccountDescription marks service account as created for the object,
+// service accounts have no labels.
+func GetServiceAccountDescription(clusterName, name string) string {
+	return config.GetCloudDescription(clusterName, name)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	connectorsv1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
+)
+
+// ReferenceKind resolves references to YandexServiceAccount into id of its service account.
+var ReferenceKind = reference.Kind{
+	Name: ysaconfig.LongName,
+	New: func() client.Object {
+		return &connectorsv1.YandexServiceAccount{}
+	},
+	Status: func(obj client.Object) (string, []metav1.Condition) {
+		casted := obj.(*connectorsv1.YandexServiceAccount)
+		return casted.Status.ID, casted.Status.Conditions
+	},
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller/adapter"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+func GetServiceAccount(
+	ctx context.Context, saID, folderID, metaName, clusterName string, ad adapter.YandexServiceAccountAdapter,
+) (*iam.ServiceAccount, error) {
+	description := ysaconfig.GetServiceAccountDescription(clusterName, metaName)
+
+	// If id is written in the status, we need to check
+	// whether it exists in the cloud
+	if saID != "" {
+		sa, err := ad.Read(ctx, saID)
+		if err != nil {
+			// If service account was not found then it does not exist,
+			// but this error is not fatal, just a mismatch between
+			// out status and real world state.
+			if !errorhandling.CheckRPCErrorNotFound(err) {
+				return nil, fmt.Errorf("cannot get service account from cloud: %w", err)
+			}
+		} else if sa.Description == description {
+			// If description does match with our object, then we have found it
+			return sa, nil
+		}
+	}
+
+	// Service accounts have no labels, so they are matched by description
+	lst, err := ad.List(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list service accounts in folder: %w", err)
+	}
+
+	for _, res := range lst {
+		if res.Description == description {
+			return res, nil
+		}
+	}
+
+	return nil, errorhandling.New("unable to find resource in the cloud", ysaconfig.ErrCodeYSANotFound, nil)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/validate-connectors-cloud-yandex-com-v1-yandexserviceaccount,mutating=false,failurePolicy=fail,sideEffects=None,groups=connectors.cloud.yandex.com,resources=yandexserviceaccounts,verbs=create;update;delete,versions=v1,name=vyandexserviceaccount.yandex.com,admissionReviewVersions=v1
+
+type YSAValidator struct {
+	sdk *ycsdk.SDK
+}
+
+func NewYSAValidator(sdk *ycsdk.SDK) webhook.Validator {
+	return &YSAValidator{sdk: sdk}
+}
+
+func (r *YSAValidator) ValidateCreation(ctx context.Context, log logr.Logger, obj runtime.Object) error {
+	casted := obj.(*v1.YandexServiceAccount)
+	log.Info("validate create", "name", util.NamespacedName(casted))
+
+	if err := validateRoles(casted.Spec.Roles); err != nil {
+		return err
+	}
+
+	if _, err := r.sdk.ResourceManager().Folder().Get(
+		ctx, &resourcemanager.GetFolderRequest{
+			FolderId: casted.Spec.FolderID,
+		},
+	); err != nil {
+		if errorhandling.CheckRPCErrorNotFound(err) {
+			return webhook.NewValidationErrorf("folder %s cannot be found in the cloud", casted.Spec.FolderID)
+		}
+		return fmt.Errorf("unable to get folder: %w", err)
+	}
+
+	return nil
+}
+
+func (r *YSAValidator) ValidateUpdate(_ context.Context, log logr.Logger, current, old runtime.Object) error {
+	castedOld, castedCurrent := old.(*v1.YandexServiceAccount), current.(*v1.YandexServiceAccount)
+
+	log.Info("validate update", "name", util.NamespacedName(castedCurrent))
+
+	if castedCurrent.Spec.FolderID != castedOld.Spec.FolderID {
+		return webhook.NewValidationErrorf(
+			"folder id must be immutable, was changed from %s to %s",
+			castedOld.Spec.FolderID,
+			castedCurrent.Spec.FolderID,
+		)
+	}
+
+	return validateRoles(castedCurrent.Spec.Roles)
+}
+
+func (r *YSAValidator) ValidateDeletion(_ context.Context, log logr.Logger, obj runtime.Object) error {
+	log.Info("validate delete", "name", util.NamespacedName(obj.(*v1.YandexServiceAccount)))
+	return nil
+}
+
+func validateRoles(roles []string) error {
+	for i, role := range roles {
+		if role == "" {
+			return webhook.NewValidationErrorf("role must not be empty")
+		}
+		if util.ContainsString(roles[:i], role) {
+			return webhook.NewValidationErrorf("role %s is listed more than once", role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/webhook"
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+func setupValidation(t *testing.T) (context.Context, webhook.Validator, logr.Logger) {
+	t.Helper()
+	return context.TODO(), &YSAValidator{}, logrfake.NewFakeLogger(t)
+}
+
+func TestUpdateValidation(t *testing.T) {
+	t.Run("roles-change-is-valid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{Name: "sa", FolderID: "folder"},
+		}
+		current := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{Name: "sa", FolderID: "folder", Roles: []string{"storage.editor"}},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("folder-ID-change-is-invalid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{Name: "sa", FolderID: "folder"},
+		}
+		current := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{Name: "sa", FolderID: "other-folder"},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+
+	t.Run("duplicate-role-is-invalid-update", func(t *testing.T) {
+		// Arrange
+		ctx, wh, log := setupValidation(t)
+		old := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{Name: "sa", FolderID: "folder"},
+		}
+		current := v1.YandexServiceAccount{
+			Spec: v1.YandexServiceAccountSpec{
+				Name: "sa", FolderID: "folder", Roles: []string{"storage.editor", "storage.editor"},
+			},
+		}
+
+		// Act
+		err := wh.ValidateUpdate(ctx, log, &current, &old)
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, &webhook.ValidationError{}))
+	})
+}
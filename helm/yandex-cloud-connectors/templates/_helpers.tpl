@@ -0,0 +1,24 @@
+{{/*
+connectorEnabled renders "true" if the manager runs connector with the given short name. Connectors listed
+in .Values.connectors are the only ones run if it is not empty, otherwise connector is run unless it is
+turned off with explicit enabled: false in managerConfig.
+Usage: include "yandex-cloud-connectors.connectorEnabled" (list $ "sakey")
+*/}}
+{{- define "yandex-cloud-connectors.connectorEnabled" -}}
+{{- $root := index . 0 -}}
+{{- $name := index . 1 -}}
+{{- if $root.Values.connectors -}}
+{{- if has $name $root.Values.connectors }}true{{ end -}}
+{{- else -}}
+{{- $connectors := ($root.Values.managerConfig | default dict).connectors | default dict -}}
+{{- $connector := index $connectors $name | default dict -}}
+{{- if ne (toString (index $connector "enabled")) "false" }}true{{ end -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+fullname prefixes cluster-scoped objects of the release, so that several managers can run side by side.
+*/}}
+{{- define "yandex-cloud-connectors.fullname" -}}
+{{- .Release.Name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
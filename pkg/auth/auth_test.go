@@ -0,0 +1,147 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-sdk/iamkey"
+)
+
+func writeKey(t *testing.T, path, id string) {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key := &iamkey.Key{
+		Id:         id,
+		Subject:    &iamkey.Key_ServiceAccountId{ServiceAccountId: "sa"},
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})),
+	}
+	require.NoError(t, iamkey.WriteToJSONFile(path, key))
+}
+
+func keyID(t *testing.T, c *KeyFileCredentials) string {
+	t.Helper()
+	req, err := c.IAMTokenRequest()
+	require.NoError(t, err)
+	jwt, ok := req.Identity.(*iampb.CreateIamTokenRequest_Jwt)
+	require.True(t, ok)
+
+	header, err := base64.RawURLEncoding.DecodeString(strings.Split(jwt.Jwt, ".")[0])
+	require.NoError(t, err)
+	var decoded struct {
+		Kid string `json:"kid"`
+	}
+	require.NoError(t, json.Unmarshal(header, &decoded))
+	return decoded.Kid
+}
+
+func TestKeyFileCredentials(t *testing.T) {
+	t.Run("changed key is reloaded", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "key")
+		writeKey(t, path, "first")
+		c, err := NewKeyFileCredentials(logr.Discard(), path, 0)
+		require.NoError(t, err)
+		writeKey(t, path, "second")
+
+		// Act
+		changed, err := c.Reload()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "second", keyID(t, c))
+	})
+
+	t.Run("unchanged key is not reloaded", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "key")
+		writeKey(t, path, "first")
+		c, err := NewKeyFileCredentials(logr.Discard(), path, 0)
+		require.NoError(t, err)
+
+		// Act
+		changed, err := c.Reload()
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("malformed key keeps previous one", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "key")
+		writeKey(t, path, "first")
+		c, err := NewKeyFileCredentials(logr.Discard(), path, 0)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+
+		// Act
+		changed, err := c.Reload()
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "first", keyID(t, c))
+	})
+}
+
+func TestTokenExchangeCredentials(t *testing.T) {
+	t.Run("service account token is exchanged for iam token", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.FormValue("grant_type") != tokenExchangeGrantType ||
+				req.FormValue("subject_token") != "k8s-token" ||
+				req.FormValue("audience") != "sa" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "iam-token", "expires_in": 3600}`))
+		}))
+		defer server.Close()
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, ioutil.WriteFile(tokenFile, []byte("k8s-token\n"), 0600))
+
+		// Act
+		resp, err := NewTokenExchangeCredentials(tokenFile, server.URL, "sa").IAMToken(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "iam-token", resp.IamToken)
+		assert.NotNil(t, resp.ExpiresAt)
+	})
+
+	t.Run("rejected exchange is an error", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, ioutil.WriteFile(tokenFile, []byte("k8s-token"), 0600))
+
+		// Act
+		_, err := NewTokenExchangeCredentials(tokenFile, server.URL, "sa").IAMToken(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"github.com/yandex-cloud/go-sdk/iamkey"
+)
+
+// KeyFileCredentials are service account key credentials that follow changes of the key file.
+// Key mounted from a secret is updated by kubelet in place, so rotating the secret is enough
+// for the manager to start using new key. SDK keeps using already issued IAM token until it
+// expires, every token requested after reload is signed with the new key.
+type KeyFileCredentials struct {
+	log      logr.Logger
+	path     string
+	interval time.Duration
+
+	mutex   sync.RWMutex
+	raw     []byte
+	current ycsdk.ExchangeableCredentials
+}
+
+// NewKeyFileCredentials reads the key file, it is an error if the file is missing or malformed.
+func NewKeyFileCredentials(log logr.Logger, path string, interval time.Duration) (*KeyFileCredentials, error) {
+	c := &KeyFileCredentials{
+		log:      log,
+		path:     filepath.Clean(path),
+		interval: interval,
+	}
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *KeyFileCredentials) YandexCloudAPICredentials() {}
+
+// IAMTokenRequest implements ycsdk.ExchangeableCredentials using the latest loaded key.
+func (c *KeyFileCredentials) IAMTokenRequest() (*iampb.CreateIamTokenRequest, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.current.IAMTokenRequest()
+}
+
+// Reload re-reads the key file and reports whether the key has changed. Previous key
+// is kept if the file can not be read or parsed.
+func (c *KeyFileCredentials) Reload() (bool, error) {
+	raw, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return false, fmt.Errorf("unable to read service account key file: %w", err)
+	}
+
+	c.mutex.RLock()
+	unchanged := bytes.Equal(raw, c.raw)
+	c.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	creds, err := parseKey(raw)
+	if err != nil {
+		return false, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.raw = raw
+	c.current = creds
+	return true, nil
+}
+
+// Start implements manager.Runnable, it polls the key file until context is done.
+func (c *KeyFileCredentials) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changed, err := c.Reload()
+		if err != nil {
+			c.log.Error(err, "unable to reload service account key")
+			continue
+		}
+		if changed {
+			c.log.Info("service account key reloaded", "path", c.path)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every
+// replica of the manager must use the actual key.
+func (c *KeyFileCredentials) NeedLeaderElection() bool {
+	return false
+}
+
+func parseKey(raw []byte) (ycsdk.ExchangeableCredentials, error) {
+	key, err := iamkey.ReadFromJSONBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse service account key: %w", err)
+	}
+
+	creds, err := ycsdk.ServiceAccountKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create credentials from service account key: %w", err)
+	}
+
+	exchangeable, ok := creds.(ycsdk.ExchangeableCredentials)
+	if !ok {
+		return nil, fmt.Errorf("service account key credentials are not exchangeable")
+	}
+	return exchangeable, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultTokenExchangeEndpoint = "https://auth.yandex.cloud/oauth/token"
+
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	accessTokenType        = "urn:ietf:params:oauth:token-type:access_token"
+	idTokenType            = "urn:ietf:params:oauth:token-type:id_token"
+	tokenExchangeTimeout   = 10 * time.Second
+)
+
+// TokenExchangeCredentials exchange projected Kubernetes service account token for IAM token
+// of the cloud service account federated with it, see RFC 8693. Token file is re-read on every
+// exchange, so token rotation by kubelet is picked up.
+type TokenExchangeCredentials struct {
+	tokenFile        string
+	endpoint         string
+	serviceAccountID string
+	client           *http.Client
+}
+
+func NewTokenExchangeCredentials(tokenFile, endpoint, serviceAccountID string) *TokenExchangeCredentials {
+	return &TokenExchangeCredentials{
+		tokenFile:        filepath.Clean(tokenFile),
+		endpoint:         endpoint,
+		serviceAccountID: serviceAccountID,
+		client:           &http.Client{Timeout: tokenExchangeTimeout},
+	}
+}
+
+func (c *TokenExchangeCredentials) YandexCloudAPICredentials() {}
+
+// IAMToken implements ycsdk.NonExchangeableCredentials.
+func (c *TokenExchangeCredentials) IAMToken(ctx context.Context) (*iampb.CreateIamTokenResponse, error) {
+	subjectToken, err := ioutil.ReadFile(c.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read service account token: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":           {tokenExchangeGrantType},
+		"requested_token_type": {accessTokenType},
+		"audience":             {c.serviceAccountID},
+		"subject_token":        {strings.TrimSpace(string(subjectToken))},
+		"subject_token_type":   {idTokenType},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to exchange service account token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token exchange response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed with status %s: %s", resp.Status, body)
+	}
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("unable to decode token exchange response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response does not contain access token")
+	}
+
+	return &iampb.CreateIamTokenResponse{
+		IamToken:  tokenResponse.AccessToken,
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)),
+	}, nil
+}
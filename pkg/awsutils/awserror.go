@@ -11,6 +11,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 )
 
+// ErrCodeS3NoSuchTagSet is returned on request of tags of bucket that has none, SDK does not define it.
+const ErrCodeS3NoSuchTagSet = "NoSuchTagSet"
+
 func checkAWSErrorByCode(err error, code string) bool {
 	var s awserr.Error
 	ok := errors.As(err, &s)
@@ -28,6 +31,10 @@ func CheckS3DoesNotExist(err error) bool {
 	return checkAWSErrorByCode(err, s3.ErrCodeNoSuchBucket)
 }
 
+func CheckS3NoSuchTagSet(err error) bool {
+	return checkAWSErrorByCode(err, ErrCodeS3NoSuchTagSet)
+}
+
 func CheckS3AlreadyOwnedByYou(err error) bool {
 	return checkAWSErrorByCode(err, s3.ErrCodeBucketAlreadyOwnedByYou)
 }
@@ -0,0 +1,190 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package awsutils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// ClientCache shares clients of AWS-compatible services between controllers and webhooks. Credentials
+// are read once per StaticAccessKey and clients are built once per credentials and endpoint, so that
+// their sessions and connections are reused. Entries are invalidated once the key or its secret changes.
+type ClientCache struct {
+	mu sync.Mutex
+	// generation is incremented on every invalidation, so that credentials read concurrently with it are not cached
+	generation  uint64
+	credentials map[types.NamespacedName]cachedCredentials
+	clients     map[clientKey]interface{}
+}
+
+type cachedCredentials struct {
+	cred   *credentials.Credentials
+	secret types.NamespacedName
+}
+
+type clientKey struct {
+	cred     *credentials.Credentials
+	endpoint string
+}
+
+func NewClientCache() *ClientCache {
+	return &ClientCache{
+		credentials: map[types.NamespacedName]cachedCredentials{},
+		clients:     map[clientKey]interface{}{},
+	}
+}
+
+// GrantedCredentials is the same as GrantedCredentials, but reads credentials only if they are not cached.
+func (c *ClientCache) GrantedCredentials(
+	ctx context.Context, kind, namespace string, key types.NamespacedName, cl client.Reader,
+) (*credentials.Credentials, error) {
+	if err := sakeyutils.CheckGrant(ctx, cl, kind, namespace, key); err != nil {
+		return nil, err
+	}
+	return c.Credentials(ctx, key, cl)
+}
+
+// Credentials returns credentials of the key, reading them only if they are not cached.
+func (c *ClientCache) Credentials(
+	ctx context.Context, key types.NamespacedName, cl client.Reader,
+) (*credentials.Credentials, error) {
+	c.mu.Lock()
+	cached, ok := c.credentials[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok {
+		return cached.cred, nil
+	}
+
+	cred, secret, err := readCredentials(ctx, cl, key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.credentials[key]; ok {
+		return cached.cred, nil
+	}
+	if c.generation == generation {
+		c.credentials[key] = cachedCredentials{cred: cred, secret: secret}
+	}
+	return cred, nil
+}
+
+// Client returns client for the endpoint built with the credentials, calling build only if it is not cached.
+// Clients are cached only for cached credentials, so that they are invalidated together.
+func (c *ClientCache) Client(
+	cred *credentials.Credentials, endpoint string, build func() (interface{}, error),
+) (interface{}, error) {
+	key := clientKey{cred: cred, endpoint: endpoint}
+
+	c.mu.Lock()
+	cached, ok := c.clients[key]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	sdk, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[key]; ok {
+		return cached, nil
+	}
+	for _, cached := range c.credentials {
+		if cached.cred == cred {
+			c.clients[key] = sdk
+			break
+		}
+	}
+	return sdk, nil
+}
+
+// InvalidateKey forgets credentials of the key and clients built with them.
+func (c *ClientCache) InvalidateKey(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if cached, ok := c.credentials[key]; ok {
+		c.forget(key, cached.cred)
+	}
+}
+
+// InvalidateSecret forgets credentials stored in the secret and clients built with them.
+func (c *ClientCache) InvalidateSecret(secret types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key, cached := range c.credentials {
+		if cached.secret == secret {
+			c.forget(key, cached.cred)
+		}
+	}
+}
+
+func (c *ClientCache) forget(key types.NamespacedName, cred *credentials.Credentials) {
+	delete(c.credentials, key)
+	for sdk := range c.clients {
+		if sdk.cred == cred {
+			delete(c.clients, sdk)
+		}
+	}
+}
+
+// Watch invalidates the cache on changes and deletions of StaticAccessKeys and their secrets.
+func (c *ClientCache) Watch(ctx context.Context, informers cache.Informers) error {
+	secrets, err := informers.GetInformer(ctx, &v1.Secret{})
+	if err != nil {
+		return fmt.Errorf("unable to get informer of secrets: %w", err)
+	}
+	secrets.AddEventHandler(invalidationHandler(c.InvalidateSecret))
+
+	keys, err := informers.GetInformer(ctx, &sakey.StaticAccessKey{})
+	if err != nil {
+		return fmt.Errorf("unable to get informer of static access keys: %w", err)
+	}
+	keys.AddEventHandler(invalidationHandler(c.InvalidateKey))
+
+	return nil
+}
+
+// invalidationHandler calls invalidate on every change and deletion of object, resyncs are skipped.
+func invalidationHandler(invalidate func(name types.NamespacedName)) toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			old, oldOK := oldObj.(client.Object)
+			current, currentOK := newObj.(client.Object)
+			if !oldOK || !currentOK || old.GetResourceVersion() == current.GetResourceVersion() {
+				return
+			}
+			invalidate(util.NamespacedName(old))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if deleted, ok := obj.(client.Object); ok {
+				invalidate(util.NamespacedName(deleted))
+			}
+		},
+	}
+}
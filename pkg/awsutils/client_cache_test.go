@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package awsutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+)
+
+func createSAKey(ctx context.Context, t *testing.T, cl client.Client, name types.NamespacedName) {
+	t.Helper()
+	require.NoError(t, cl.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name + "-secret"},
+		Data:       map[string][]byte{"key": []byte("key"), "secret": []byte("secret")},
+	}))
+	require.NoError(t, cl.Create(ctx, &sakey.StaticAccessKey{
+		ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+		Status:     sakey.StaticAccessKeyStatus{SecretName: name.Name + "-secret"},
+	}))
+}
+
+func TestClientCache(t *testing.T) {
+	t.Run("credentials are read once until their secret changes", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		key := types.NamespacedName{Namespace: "default", Name: "sakey"}
+		other := types.NamespacedName{Namespace: "default", Name: "other"}
+		createSAKey(ctx, t, cl, key)
+		createSAKey(ctx, t, cl, other)
+		clients := NewClientCache()
+
+		// Act
+		first, err := clients.Credentials(ctx, key, cl)
+		require.NoError(t, err)
+		cached, err := clients.Credentials(ctx, key, cl)
+		require.NoError(t, err)
+		otherFirst, err := clients.Credentials(ctx, other, cl)
+		require.NoError(t, err)
+		clients.InvalidateSecret(types.NamespacedName{Namespace: "default", Name: "sakey-secret"})
+		reread, err := clients.Credentials(ctx, key, cl)
+		require.NoError(t, err)
+		otherCached, err := clients.Credentials(ctx, other, cl)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Same(t, first, cached)
+		assert.NotSame(t, first, reread)
+		assert.Same(t, otherFirst, otherCached)
+	})
+
+	t.Run("client is built once per credentials and endpoint until key changes", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		key := types.NamespacedName{Namespace: "default", Name: "sakey"}
+		createSAKey(ctx, t, cl, key)
+		clients := NewClientCache()
+		builds := 0
+		build := func() (interface{}, error) {
+			builds++
+			return builds, nil
+		}
+
+		// Act
+		cred, err := clients.Credentials(ctx, key, cl)
+		require.NoError(t, err)
+		_, err = clients.Client(cred, "endpoint", build)
+		require.NoError(t, err)
+		_, err = clients.Client(cred, "endpoint", build)
+		require.NoError(t, err)
+		_, err = clients.Client(cred, "other-endpoint", build)
+		require.NoError(t, err)
+		clients.InvalidateKey(key)
+		_, err = clients.Client(cred, "endpoint", build)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 3, builds)
+	})
+
+	t.Run("client of not cached credentials is not cached", func(t *testing.T) {
+		// Arrange
+		clients := NewClientCache()
+		cred := credentials.NewStaticCredentials("key", "secret", "")
+		builds := 0
+		build := func() (interface{}, error) {
+			builds++
+			return builds, nil
+		}
+
+		// Act
+		_, err := clients.Client(cred, "endpoint", build)
+		require.NoError(t, err)
+		_, err = clients.Client(cred, "endpoint", build)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 2, builds)
+	})
+}
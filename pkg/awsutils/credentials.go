@@ -9,34 +9,70 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/reference"
 )
 
+// StaticAccessKeyName returns name of StaticAccessKey given either with its name or with reference to it.
+// Namespace of the key is namespace of the object using it, unless the key is explicitly given with another one.
+func StaticAccessKeyName(
+	namespace, sakeyNamespace, sakeyName string, ref *reference.ObjectReference,
+) types.NamespacedName {
+	if ref != nil {
+		return ref.NamespacedName(namespace)
+	}
+	if sakeyNamespace != "" {
+		namespace = sakeyNamespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: sakeyName}
+}
+
+// GrantedCredentials returns credentials of StaticAccessKey used by object of kind in namespace.
+// Key of another namespace is used only if it is granted to the object with StaticAccessKeyGrant.
+func GrantedCredentials(
+	ctx context.Context, kind, namespace string, key types.NamespacedName, cl client.Client,
+) (*credentials.Credentials, error) {
+	if err := sakeyutils.CheckGrant(ctx, cl, kind, namespace, key); err != nil {
+		return nil, err
+	}
+	return CredentialsFromStaticAccessKey(ctx, key.Namespace, key.Name, cl)
+}
+
+// CheckCredentialsGone reports whether credentials cannot be retrieved because the key, its secret
+// or the grant to use it no longer exist, so that retrying is pointless until they are recreated.
+func CheckCredentialsGone(err error) bool {
+	return apierrors.IsNotFound(err) || errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotGranted)
+}
+
 func CredentialsFromStaticAccessKey(
 	ctx context.Context, namespace, sakeyName string, cl client.Client,
 ) (*credentials.Credentials, error) {
+	cred, _, err := readCredentials(ctx, cl, types.NamespacedName{Namespace: namespace, Name: sakeyName})
+	return cred, err
+}
+
+// readCredentials returns credentials of the key together with name of the secret they are stored in.
+func readCredentials(
+	ctx context.Context, cl client.Reader, name types.NamespacedName,
+) (*credentials.Credentials, types.NamespacedName, error) {
 	var key sakey.StaticAccessKey
-	if err := cl.Get(
-		ctx, types.NamespacedName{
-			Namespace: namespace,
-			Name:      sakeyName,
-		}, &key,
-	); err != nil {
-		return nil, fmt.Errorf("unable to retrieve corresponding SAKey: %w", err)
+	if err := cl.Get(ctx, name, &key); err != nil {
+		return nil, types.NamespacedName{}, fmt.Errorf("unable to retrieve corresponding SAKey: %w", err)
 	}
 
+	secretName := types.NamespacedName{Namespace: name.Namespace, Name: key.Status.SecretName}
 	var secret v1.Secret
-	if err := cl.Get(
-		ctx, types.NamespacedName{
-			Namespace: namespace,
-			Name:      key.Status.SecretName,
-		}, &secret,
-	); err != nil {
-		return nil, fmt.Errorf("unable to retrieve corresponding secret: %w", err)
+	if err := cl.Get(ctx, secretName, &secret); err != nil {
+		return nil, types.NamespacedName{}, fmt.Errorf("unable to retrieve corresponding secret: %w", err)
 	}
 
-	return credentials.NewStaticCredentials(string(secret.Data["key"]), string(secret.Data["secret"]), ""), nil
+	cred := credentials.NewStaticCredentials(string(secret.Data["key"]), string(secret.Data["secret"]), "")
+	return cred, secretName, nil
 }
@@ -0,0 +1,41 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package awsutils
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CredentialsCache keeps last retrieved credentials of objects, so that objects can still
+// clean up their cloud resources if their StaticAccessKey is deleted before them.
+type CredentialsCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]*credentials.Credentials
+}
+
+func NewCredentialsCache() *CredentialsCache {
+	return &CredentialsCache{entries: map[types.UID]*credentials.Credentials{}}
+}
+
+func (c *CredentialsCache) Put(uid types.UID, cred *credentials.Credentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[uid] = cred
+}
+
+func (c *CredentialsCache) Get(uid types.UID) (*credentials.Credentials, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cred, ok := c.entries[uid]
+	return cred, ok
+}
+
+func (c *CredentialsCache) Forget(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, uid)
+}
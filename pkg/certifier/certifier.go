@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kubernetesPollInterval = time.Second
+	// CertifyTimeout is the time one run of the signing flow is allowed to take.
+	CertifyTimeout = 5 * time.Minute
+)
+
+const (
+	TLSKeyName  = "tls.key"
+	TLSCertName = "tls.crt"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests/approval,verbs=update
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=signers,resourceNames=kubernetes.io/*,verbs=approve
+
+// Config describes where the webhook serving certificate is issued for and where it is delivered to.
+type Config struct {
+	SecretName         string
+	ServiceName        string
+	Namespace          string
+	MutatingWebhooks   []string
+	ValidatingWebhooks []string
+}
+
+// Certify issues a new webhook serving certificate via Kubernetes CSR API, puts it
+// into the configured secret and adds it to caBundle of all configured webhook configurations.
+func Certify(ctx context.Context, log logr.Logger, cl kubernetes.Interface, config Config) error {
+	key, err := createSecretKey(log)
+	if err != nil {
+		return fmt.Errorf("unable to generate secret key: %w", err)
+	}
+
+	csr, err := createCertificateRequest(log, key, config.ServiceName, config.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to create certificate: %w", err)
+	}
+
+	cert, err := signCertificate(ctx, log, cl, config.ServiceName, config.Namespace, csr)
+	if err != nil {
+		return fmt.Errorf("unable to sign certificate: %w", err)
+	}
+
+	previous, err := currentCertificate(ctx, cl.CoreV1().Secrets(config.Namespace), config.SecretName)
+	if err != nil {
+		return fmt.Errorf("unable to read current certificate: %w", err)
+	}
+	// Webhooks are made to trust the new certificate before it is served, the current one
+	// stays trusted until the manager picks the new one up
+	bundle := mergeCABundle(cert, previous, time.Now())
+	for _, webhook := range config.MutatingWebhooks {
+		log.Info("patching mutating webhook: " + webhook)
+		if err := patchMutatingConfig(ctx, cl, webhook, config.Namespace, bundle); err != nil {
+			return fmt.Errorf("unable to patch config: %w", err)
+		}
+	}
+
+	for _, webhook := range config.ValidatingWebhooks {
+		log.Info("patching validating webhook: " + webhook)
+		if err := patchValidatingConfig(ctx, cl, webhook, config.Namespace, bundle); err != nil {
+			return fmt.Errorf("unable to patch config: %w", err)
+		}
+	}
+
+	if err := putKeyAndCertToSecret(
+		ctx,
+		log,
+		cl.CoreV1().Secrets(config.Namespace),
+		config.Namespace,
+		config.SecretName,
+		encodeSecretKey(key),
+		cert,
+	); err != nil {
+		return fmt.Errorf("unable to create secret with certificate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	certificates "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/typed/certificates/v1beta1"
+)
+
+func createCSR(
+	ctx context.Context,
+	cl v1beta1.CertificateSigningRequestInterface,
+	name,
+	namespace string,
+	bytes []byte,
+) (*certificates.CertificateSigningRequest, error) {
+	return cl.Create(
+		ctx,
+		&certificates.CertificateSigningRequest{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CertificateSigningRequest",
+				APIVersion: "certificates.k8s.io/v1beta1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: certificates.CertificateSigningRequestSpec{
+				Request: bytes,
+				Usages: []certificates.KeyUsage{
+					certificates.UsageDigitalSignature,
+					certificates.UsageKeyEncipherment,
+					certificates.UsageServerAuth,
+				},
+				Groups: []string{"system:authenticated"},
+			},
+		},
+		metav1.CreateOptions{},
+	)
+}
+
+func waitForDeletion(
+	ctx context.Context,
+	log logr.Logger,
+	csrClient v1beta1.CertificateSigningRequestInterface,
+	csrName string,
+) error {
+	log.Info("old CSR found, waiting for its deletion to be completed")
+	for {
+		if _, err := csrClient.Get(ctx, csrName, metav1.GetOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CertificateSigningRequest",
+				APIVersion: "certificates.k8s.io/v1beta1",
+			},
+		}); err != nil {
+			if errors.IsNotFound(err) {
+				break
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(kubernetesPollInterval):
+			log.Info("deletion is not completed, waiting")
+		}
+	}
+	log.Info("deletion is completed")
+	return nil
+}
+
+func waitForCreation(
+	ctx context.Context,
+	log logr.Logger,
+	csrClient v1beta1.CertificateSigningRequestInterface,
+	csrName string,
+) error {
+	log.Info("waiting for CSR creation")
+	for {
+		_, err := csrClient.Get(ctx, csrName, metav1.GetOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CertificateSigningRequest",
+				APIVersion: "certificates.k8s.io/v1beta1",
+			},
+		})
+		if err == nil {
+			break
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(kubernetesPollInterval):
+			log.Info("creation is not completed, waiting")
+		}
+	}
+	log.Info("creation is completed")
+	return nil
+}
+
+func signCertificate(
+	ctx context.Context,
+	log logr.Logger,
+	cl kubernetes.Interface,
+	service,
+	namespace string,
+	csrBytes []byte,
+) ([]byte, error) {
+	csrName := namespace + "." + service + ".csr"
+	csrClient := cl.CertificatesV1beta1().CertificateSigningRequests()
+
+	if err := csrClient.Delete(
+		ctx,
+		csrName,
+		metav1.DeleteOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CertificateSigningRequest",
+				APIVersion: "certificates.k8s.io/v1beta1",
+			},
+		},
+	); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to delete previous CSR %w", err)
+		}
+		log.Info("old CSR not found")
+	} else if err := waitForDeletion(ctx, log, csrClient, csrName); err != nil {
+		return nil, fmt.Errorf("error while waiting for old CSR deletion: %w", err)
+	}
+
+	log.Info("creating new CSR")
+	csr, err := createCSR(ctx, csrClient, csrName, namespace, csrBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create CSR: %w", err)
+	}
+
+	if err := waitForCreation(ctx, log, csrClient, csrName); err != nil {
+		return nil, fmt.Errorf("error while waiting for CSR creation: %w", err)
+	}
+
+	log.Info("approving CSR")
+	csr.Status.Conditions = append(csr.Status.Conditions, certificates.CertificateSigningRequestCondition{
+		Type:           certificates.CertificateApproved,
+		LastUpdateTime: metav1.Now(),
+	})
+
+	if _, err := csrClient.UpdateApproval(ctx, csr, metav1.UpdateOptions{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CertificateSigningRequest",
+			APIVersion: "certificates.k8s.io/v1beta1",
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("unable to approve CSR: %w", err)
+	}
+
+	log.Info("waiting for CSR to be approved")
+	var cert []byte
+	for {
+		res, err := csrClient.Get(ctx, csrName, metav1.GetOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CertificateSigningRequest",
+				APIVersion: "certificates.k8s.io/v1beta1",
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while waiting for CSR approval: %w", err)
+		}
+		if res.Status.Certificate != nil && len(res.Status.Certificate) != 0 {
+			cert = res.Status.Certificate
+			log.Info("CSR is approved")
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for CSR approval interrupted: %w", ctx.Err())
+		case <-time.After(kubernetesPollInterval):
+			log.Info("CSR approval is not completed, waiting")
+		}
+	}
+
+	return cert, nil
+}
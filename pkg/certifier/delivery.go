@@ -0,0 +1,201 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typed "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func putKeyAndCertToSecret(
+	ctx context.Context,
+	log logr.Logger,
+	si typed.SecretInterface,
+	namespace,
+	secret string,
+	key,
+	cert []byte,
+) error {
+	secretType := metav1.TypeMeta{
+		Kind:       "Secret",
+		APIVersion: "v1",
+	}
+
+	sec := v1.Secret{
+		TypeMeta: secretType,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			TLSKeyName:  key,
+			TLSCertName: cert,
+		},
+	}
+
+	if _, err := si.Get(ctx, secret, metav1.GetOptions{
+		TypeMeta: secretType,
+	}); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("secret does not exist, creating")
+			return createSecret(ctx, log, si, &sec)
+		}
+		return fmt.Errorf("unable to get secret: %w", err)
+	}
+
+	log.Info("secret already exists, updating")
+	return updateSecret(ctx, log, si, &sec)
+}
+
+func updateSecret(
+	ctx context.Context,
+	log logr.Logger,
+	si typed.SecretInterface,
+	sec *v1.Secret,
+) error {
+	if _, err := si.Update(ctx, sec, metav1.UpdateOptions{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+	}); err != nil {
+		return fmt.Errorf("unable to update secret: %w", err)
+	}
+	log.Info("secret with key and cert successfully updated")
+
+	return nil
+}
+
+func createSecret(
+	ctx context.Context,
+	log logr.Logger,
+	si typed.SecretInterface,
+	sec *v1.Secret,
+) error {
+	if _, err := si.Create(ctx, sec, metav1.CreateOptions{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+	}); err != nil {
+		return fmt.Errorf("unable to create secret: %w", err)
+	}
+	log.Info("secret with key and cert successfully created")
+
+	return nil
+}
+
+func patchMutatingConfig(
+	ctx context.Context,
+	cl kubernetes.Interface,
+	webhook,
+	namespace string,
+	caBundle []byte,
+) error {
+	confClient := cl.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	confTypeMeta := metav1.TypeMeta{
+		Kind:       "MutatingWebhookConfiguration",
+		APIVersion: "admissionregistration.k8s.io/v1",
+	}
+
+	conf, err := confClient.Get(ctx, webhook, metav1.GetOptions{
+		TypeMeta: confTypeMeta,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get webhook configuration: %w", err)
+	}
+
+	for i := range conf.Webhooks {
+		conf.Webhooks[i].ClientConfig.Service.Namespace = namespace
+		conf.Webhooks[i].ClientConfig.CABundle = caBundle
+	}
+
+	if _, err := confClient.Update(ctx, conf, metav1.UpdateOptions{
+		TypeMeta: confTypeMeta,
+	}); err != nil {
+		return fmt.Errorf("unable to update webhook configuration: %w", err)
+	}
+
+	return nil
+}
+
+func patchValidatingConfig(
+	ctx context.Context,
+	cl kubernetes.Interface,
+	webhook,
+	namespace string,
+	caBundle []byte,
+) error {
+	confClient := cl.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	confTypeMeta := metav1.TypeMeta{
+		Kind:       "ValidatingWebhookConfiguration",
+		APIVersion: "admissionregistration.k8s.io/v1",
+	}
+
+	conf, err := confClient.Get(ctx, webhook, metav1.GetOptions{
+		TypeMeta: confTypeMeta,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get webhook configuration: %w", err)
+	}
+
+	for i := range conf.Webhooks {
+		conf.Webhooks[i].ClientConfig.Service.Namespace = namespace
+		conf.Webhooks[i].ClientConfig.CABundle = caBundle
+	}
+
+	if _, err := confClient.Update(ctx, conf, metav1.UpdateOptions{
+		TypeMeta: confTypeMeta,
+	}); err != nil {
+		return fmt.Errorf("unable to update webhook configuration: %w", err)
+	}
+
+	return nil
+}
+
+// currentCertificate returns certificate stored in the secret, or nil if there is no secret yet.
+func currentCertificate(ctx context.Context, si typed.SecretInterface, secret string) ([]byte, error) {
+	sec, err := si.Get(ctx, secret, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get secret: %w", err)
+	}
+	return sec.Data[TLSCertName], nil
+}
+
+// mergeCABundle returns bundle that trusts the new certificate together with the previous one.
+// Manager keeps serving the previous certificate until kubelet syncs the mounted secret, so it
+// stays trusted until the next rotation. Previous certificate is left out if it is expired or malformed.
+func mergeCABundle(cert, previous []byte, now time.Time) []byte {
+	block, _ := pem.Decode(previous)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return cert
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || now.After(parsed.NotAfter) {
+		return cert
+	}
+	encoded := pem.EncodeToMemory(block)
+	if string(encoded) == string(cert) {
+		return cert
+	}
+
+	res := append([]byte{}, cert...)
+	if len(res) != 0 && res[len(res)-1] != '\n' {
+		res = append(res, '\n')
+	}
+	return append(res, encoded...)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+const serviceDNSName = "webhook-service.connectors.svc"
+
+func createServingCertificate(t *testing.T, serial int64, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := createSecretKey(logrfake.NewFakeLogger(t))
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: serviceDNSName},
+		DNSNames:              []string{serviceDNSName},
+		NotBefore:             notAfter.Add(-48 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func trusts(t *testing.T, bundle, cert []byte, now time.Time) bool {
+	t.Helper()
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(bundle))
+	block, _ := pem.Decode(cert)
+	require.NotNil(t, block)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	_, err = parsed.Verify(x509.VerifyOptions{DNSName: serviceDNSName, Roots: pool, CurrentTime: now})
+	return err == nil
+}
+
+func TestMergeCABundle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("bundle trusts both new and previous certificates", func(t *testing.T) {
+		// Arrange
+		previous := createServingCertificate(t, 1, now.Add(12*time.Hour))
+		cert := createServingCertificate(t, 2, now.Add(24*time.Hour))
+
+		// Act
+		bundle := mergeCABundle(cert, previous, now)
+
+		// Assert
+		assert.True(t, trusts(t, bundle, cert, now))
+		assert.True(t, trusts(t, bundle, previous, now))
+	})
+
+	t.Run("certificate before previous is dropped on the next rotation", func(t *testing.T) {
+		// Arrange
+		oldest := createServingCertificate(t, 1, now.Add(12*time.Hour))
+		previous := createServingCertificate(t, 2, now.Add(24*time.Hour))
+		cert := createServingCertificate(t, 3, now.Add(36*time.Hour))
+
+		// Act
+		bundle := mergeCABundle(cert, mergeCABundle(previous, oldest, now), now)
+
+		// Assert
+		assert.True(t, trusts(t, bundle, cert, now))
+		assert.True(t, trusts(t, bundle, previous, now))
+		assert.False(t, trusts(t, bundle, oldest, now))
+	})
+
+	t.Run("first certificate makes the whole bundle", func(t *testing.T) {
+		// Arrange
+		cert := createServingCertificate(t, 1, now.Add(24*time.Hour))
+
+		// Act
+		bundle := mergeCABundle(cert, nil, now)
+
+		// Assert
+		assert.Equal(t, cert, bundle)
+	})
+
+	t.Run("expired previous certificate is dropped", func(t *testing.T) {
+		// Arrange
+		previous := createServingCertificate(t, 1, now.Add(-time.Hour))
+		cert := createServingCertificate(t, 2, now.Add(24*time.Hour))
+
+		// Act
+		bundle := mergeCABundle(cert, previous, now)
+
+		// Assert
+		assert.Equal(t, cert, bundle)
+	})
+
+	t.Run("reissued same certificate is not duplicated", func(t *testing.T) {
+		// Arrange
+		cert := createServingCertificate(t, 1, now.Add(24*time.Hour))
+
+		// Act
+		bundle := mergeCABundle(cert, cert, now)
+
+		// Assert
+		assert.Equal(t, cert, bundle)
+	})
+}
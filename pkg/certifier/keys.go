@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const secretKeyBits = 2048
+
+func createSecretKey(log logr.Logger) (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, secretKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate RSA key: %w", err)
+	}
+	log.Info("RSA key generated")
+
+	return key, nil
+}
+
+func encodeSecretKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+func createCertificateRequest(log logr.Logger, key *rsa.PrivateKey, service, namespace string) ([]byte, error) {
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: service + "." + namespace + ".svc",
+		},
+		DNSNames: []string{
+			service,
+			service + "." + namespace,
+			service + "." + namespace + ".svc",
+		},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create server CSR: %w", err)
+	}
+	log.Info("server CSR created")
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csr,
+	}), nil
+}
+
+// CertificateExpiration returns the expiration time of the first certificate in PEM encoded bundle.
+func CertificateExpiration(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse certificate: %w", err)
+	}
+
+	return cert.NotAfter, nil
+}
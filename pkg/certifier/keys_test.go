@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+func TestCreateCertificateRequest(t *testing.T) {
+	t.Run("request contains all service names", func(t *testing.T) {
+		// Arrange
+		log := logrfake.NewFakeLogger(t)
+		key, err := createSecretKey(log)
+		require.NoError(t, err)
+
+		// Act
+		csrPEM, err := createCertificateRequest(log, key, "webhook-service", "connectors")
+		require.NoError(t, err)
+
+		// Assert
+		block, _ := pem.Decode(csrPEM)
+		require.NotNil(t, block)
+		assert.Equal(t, "CERTIFICATE REQUEST", block.Type)
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		require.NoError(t, err)
+		assert.NoError(t, csr.CheckSignature())
+		assert.Equal(t, "webhook-service.connectors.svc", csr.Subject.CommonName)
+		assert.ElementsMatch(t, []string{
+			"webhook-service",
+			"webhook-service.connectors",
+			"webhook-service.connectors.svc",
+		}, csr.DNSNames)
+	})
+}
+
+func TestCertificateExpiration(t *testing.T) {
+	t.Run("expiration of valid certificate is read", func(t *testing.T) {
+		// Arrange
+		log := logrfake.NewFakeLogger(t)
+		key, err := createSecretKey(log)
+		require.NoError(t, err)
+		notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		template := x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "webhook-service"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+		require.NoError(t, err)
+
+		// Act
+		res, err := CertificateExpiration(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, notAfter.Equal(res))
+	})
+
+	t.Run("garbage is not a certificate", func(t *testing.T) {
+		// Act
+		_, err := CertificateExpiration([]byte("not a certificate"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestMustRenew(t *testing.T) {
+	now := time.Now()
+
+	t.Run("fresh certificate is not renewed", func(t *testing.T) {
+		assert.False(t, mustRenew(now.Add(48*time.Hour), now, 24*time.Hour))
+	})
+
+	t.Run("certificate close to expiry is renewed", func(t *testing.T) {
+		assert.True(t, mustRenew(now.Add(12*time.Hour), now, 24*time.Hour))
+	})
+
+	t.Run("expired certificate is renewed", func(t *testing.T) {
+		assert.True(t, mustRenew(now.Add(-time.Hour), now, 24*time.Hour))
+	})
+}
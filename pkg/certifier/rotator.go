@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package certifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Rotator periodically checks the webhook serving certificate and re-issues it
+// when it is about to expire. Webhook server of the manager watches certificate
+// files, so new certificate is picked up as soon as kubelet updates mounted secret.
+type Rotator struct {
+	log           logr.Logger
+	cl            kubernetes.Interface
+	config        Config
+	renewBefore   time.Duration
+	checkInterval time.Duration
+}
+
+func NewRotator(
+	log logr.Logger,
+	cl kubernetes.Interface,
+	config Config,
+	renewBefore,
+	checkInterval time.Duration,
+) *Rotator {
+	return &Rotator{
+		log:           log,
+		cl:            cl,
+		config:        config,
+		renewBefore:   renewBefore,
+		checkInterval: checkInterval,
+	}
+}
+
+// Start implements manager.Runnable.
+func (r *Rotator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.rotateIfNeeded(ctx); err != nil {
+			r.log.Error(err, "unable to rotate webhook certificate")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only one
+// replica of the manager should issue certificates at a time.
+func (r *Rotator) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *Rotator) rotateIfNeeded(ctx context.Context) error {
+	r.log.V(1).Info("checking webhook certificate")
+
+	mustRotate, err := r.mustRotate(ctx)
+	if err != nil {
+		return err
+	}
+	if !mustRotate {
+		r.log.V(1).Info("webhook certificate is up to date")
+		return nil
+	}
+
+	r.log.Info("rotating webhook certificate")
+	certifyCtx, cancel := context.WithTimeout(ctx, CertifyTimeout)
+	defer cancel()
+	if err := Certify(certifyCtx, r.log, r.cl, r.config); err != nil {
+		return fmt.Errorf("unable to certify webhook: %w", err)
+	}
+	r.log.Info("webhook certificate rotated")
+
+	return nil
+}
+
+func (r *Rotator) mustRotate(ctx context.Context) (bool, error) {
+	secret, err := r.cl.CoreV1().Secrets(r.config.Namespace).Get(ctx, r.config.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.log.Info("webhook certificate secret not found")
+			return true, nil
+		}
+		return false, fmt.Errorf("unable to get webhook certificate secret: %w", err)
+	}
+
+	notAfter, err := CertificateExpiration(secret.Data[TLSCertName])
+	if err != nil {
+		r.log.Info("webhook certificate is malformed", "error", err.Error())
+		return true, nil
+	}
+
+	return mustRenew(notAfter, time.Now(), r.renewBefore), nil
+}
+
+// mustRenew reports whether certificate expiring at notAfter must be renewed at the given moment.
+func mustRenew(notAfter, now time.Time, renewBefore time.Duration) bool {
+	return !now.Add(renewBefore).Before(notAfter)
+}
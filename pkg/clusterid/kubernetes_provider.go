@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package clusterid
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const DefaultNamespace = "kube-system"
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// NamespaceUIDProvider uses UID of a namespace that lives as long as the cluster
+// itself, kube-system by default, as identity of the cluster.
+type NamespaceUIDProvider struct {
+	reader    client.Reader
+	namespace string
+}
+
+func NewNamespaceUIDProvider(reader client.Reader, namespace string) *NamespaceUIDProvider {
+	return &NamespaceUIDProvider{reader: reader, namespace: namespace}
+}
+
+func (r *NamespaceUIDProvider) ClusterID(ctx context.Context) (string, error) {
+	var ns v1.Namespace
+	if err := r.reader.Get(ctx, types.NamespacedName{Name: r.namespace}, &ns); err != nil {
+		return "", fmt.Errorf("unable to get namespace %s: %w", r.namespace, err)
+	}
+
+	if ns.UID == "" {
+		return "", fmt.Errorf("namespace %s has no uid", r.namespace)
+	}
+	return string(ns.UID), nil
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// ConfigMapProvider reads identity of the cluster from a key of a ConfigMap.
+type ConfigMapProvider struct {
+	reader client.Reader
+	name   types.NamespacedName
+	key    string
+}
+
+func NewConfigMapProvider(reader client.Reader, name types.NamespacedName, key string) *ConfigMapProvider {
+	return &ConfigMapProvider{reader: reader, name: name, key: key}
+}
+
+func (r *ConfigMapProvider) ClusterID(ctx context.Context) (string, error) {
+	var cm v1.ConfigMap
+	if err := r.reader.Get(ctx, r.name, &cm); err != nil {
+		return "", fmt.Errorf("unable to get configmap %s: %w", r.name, err)
+	}
+
+	id, ok := cm.Data[r.key]
+	if !ok || id == "" {
+		return "", fmt.Errorf("configmap %s does not contain key %s", r.name, r.key)
+	}
+	return id, nil
+}
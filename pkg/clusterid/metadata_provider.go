@@ -0,0 +1,90 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package clusterid
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"google.golang.org/grpc"
+)
+
+const (
+	DefaultMetadataEndpoint = "http://169.254.169.254:80"
+	instanceIDPath          = "/latest/meta-data/instance-id"
+	managedClusterLabel     = "managed-kubernetes-cluster-id"
+)
+
+// InstanceGetter is the part of compute instance service used to read node labels.
+type InstanceGetter interface {
+	Get(ctx context.Context, in *compute.GetInstanceRequest, opts ...grpc.CallOption) (*compute.Instance, error)
+}
+
+// MetadataProvider reads identity of a Managed Kubernetes cluster from the label of
+// the node the manager runs on. Node is found via instance metadata service.
+type MetadataProvider struct {
+	endpoint  string
+	client    *http.Client
+	instances InstanceGetter
+}
+
+func NewMetadataProvider(endpoint string, instances InstanceGetter) *MetadataProvider {
+	return &MetadataProvider{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		client:    http.DefaultClient,
+		instances: instances,
+	}
+}
+
+func (r *MetadataProvider) ClusterID(ctx context.Context) (string, error) {
+	instanceID, err := r.instanceID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	instance, err := r.instances.Get(
+		ctx, &compute.GetInstanceRequest{
+			InstanceId: instanceID,
+			View:       compute.InstanceView_BASIC,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to get instance: %w", err)
+	}
+
+	id, ok := instance.Labels[managedClusterLabel]
+	if !ok {
+		return "", fmt.Errorf("instance does not have label with cluster id")
+	}
+
+	return id, nil
+}
+
+func (r *MetadataProvider) instanceID(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.endpoint+instanceIDPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot make request to metadata: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error while making request to metadata: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to get instanceID from metadata: %s", resp.Status)
+	}
+
+	instanceID, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("cannot read instanceID from metadata: %w", err)
+	}
+
+	return strings.TrimSpace(string(instanceID)), nil
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package clusterid
+
+import (
+	"context"
+	"fmt"
+)
+
+// Provider discovers identity of the cluster the manager runs in. Identity is used in ownership
+// labels of cloud resources, so it must be stable for the whole lifetime of the cluster.
+type Provider interface {
+	ClusterID(ctx context.Context) (string, error)
+}
+
+// StaticProvider returns identity given explicitly by the operator.
+type StaticProvider struct {
+	id string
+}
+
+func NewStaticProvider(id string) *StaticProvider {
+	return &StaticProvider{id: id}
+}
+
+func (r *StaticProvider) ClusterID(_ context.Context) (string, error) {
+	if r.id == "" {
+		return "", fmt.Errorf("cluster id is empty")
+	}
+	return r.id, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package clusterid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+)
+
+type fakeInstances map[string]*compute.Instance
+
+func (r fakeInstances) Get(
+	_ context.Context, in *compute.GetInstanceRequest, _ ...grpc.CallOption,
+) (*compute.Instance, error) {
+	instance, ok := r[in.InstanceId]
+	if !ok {
+		return nil, fmt.Errorf("instance %s not found", in.InstanceId)
+	}
+	return instance, nil
+}
+
+func newMetadataServer(t *testing.T, instanceID string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != instanceIDPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(instanceID))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMetadataProvider(t *testing.T) {
+	t.Run("cluster id is read from instance label", func(t *testing.T) {
+		// Arrange
+		server := newMetadataServer(t, "instance")
+		provider := NewMetadataProvider(server.URL, fakeInstances{
+			"instance": {Id: "instance", Labels: map[string]string{managedClusterLabel: "cluster"}},
+		})
+
+		// Act
+		id, err := provider.ClusterID(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "cluster", id)
+	})
+
+	t.Run("instance without label is an error", func(t *testing.T) {
+		// Arrange
+		server := newMetadataServer(t, "instance")
+		provider := NewMetadataProvider(server.URL, fakeInstances{
+			"instance": {Id: "instance"},
+		})
+
+		// Act
+		_, err := provider.ClusterID(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestNamespaceUIDProvider(t *testing.T) {
+	t.Run("cluster id is namespace uid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		require.NoError(t, cl.Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: DefaultNamespace, UID: "uid"},
+		}))
+
+		// Act
+		id, err := NewNamespaceUIDProvider(cl, DefaultNamespace).ClusterID(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "uid", id)
+	})
+
+	t.Run("missing namespace is an error", func(t *testing.T) {
+		// Act
+		_, err := NewNamespaceUIDProvider(k8sfake.NewFakeClient(), DefaultNamespace).ClusterID(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigMapProvider(t *testing.T) {
+	t.Run("cluster id is read from configmap", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		require.NoError(t, cl.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity", Namespace: "default"},
+			Data:       map[string]string{"cluster-id": "cluster"},
+		}))
+		provider := NewConfigMapProvider(cl, types.NamespacedName{Namespace: "default", Name: "identity"}, "cluster-id")
+
+		// Act
+		id, err := provider.ClusterID(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "cluster", id)
+	})
+
+	t.Run("missing key is an error", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cl := k8sfake.NewFakeClient()
+		require.NoError(t, cl.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity", Namespace: "default"},
+		}))
+		provider := NewConfigMapProvider(cl, types.NamespacedName{Namespace: "default", Name: "identity"}, "cluster-id")
+
+		// Act
+		_, err := provider.ClusterID(ctx)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -13,10 +13,34 @@ const (
 	CloudClusterLabel = "managed-kubernetes-cluster-id"
 	CloudNameLabel    = "managed-kubernetes-registry-metadata-name"
 
-	normalTimeout  = 30 * time.Second
-	erroredTimeout = 30 * time.Second
+	// ForceDeleteAnnotation lets object be finalized even if its cloud resource cannot be deleted.
+	ForceDeleteAnnotation = "connectors.cloud.yandex.com/force-delete"
+	// OrphanAnnotation lets object be finalized without deleting its cloud resource.
+	OrphanAnnotation = "connectors.cloud.yandex.com/orphan"
+	// PausedAnnotation stops reconciliation of object until it is removed.
+	PausedAnnotation = "connectors.cloud.yandex.com/paused"
+	// AdoptAnnotation holds id of existing cloud resource that object takes over instead of creating a new one.
+	// Queues are identified by their url, buckets are adopted by name even without the annotation.
+	AdoptAnnotation = "connectors.cloud.yandex.com/adopt"
+	// ReconcileRequestedAnnotation is bumped to make connector reconcile object immediately.
+	ReconcileRequestedAnnotation = "connectors.cloud.yandex.com/reconcile-requested-at"
+
+	DefaultNormalRequeue  = 30 * time.Second
+	DefaultErroredRequeue = 30 * time.Second
 )
 
+var (
+	normalTimeout  = DefaultNormalRequeue
+	erroredTimeout = DefaultErroredRequeue
+)
+
+// SetRequeueIntervals overrides intervals after which objects are reconciled again.
+// It must be called before any controller is started.
+func SetRequeueIntervals(normal, errored time.Duration) {
+	normalTimeout = normal
+	erroredTimeout = errored
+}
+
 func GetNormalResult() (ctrl.Result, error) {
 	return ctrl.Result{
 		RequeueAfter: normalTimeout,
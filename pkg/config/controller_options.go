@@ -0,0 +1,50 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package config
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	DefaultWorkers = 1
+	DefaultQPS     = 10
+	DefaultBurst   = 100
+
+	failureBaseDelay = 5 * time.Millisecond
+	failureMaxDelay  = 1000 * time.Second
+)
+
+// ConnectorOptions are tunable parameters of a single connector's controller.
+type ConnectorOptions struct {
+	// Workers is the number of objects reconciled concurrently.
+	Workers int
+	// QPS and Burst limit the overall rate at which objects are put into the queue.
+	QPS   float64
+	Burst int
+}
+
+func DefaultConnectorOptions() ConnectorOptions {
+	return ConnectorOptions{
+		Workers: DefaultWorkers,
+		QPS:     DefaultQPS,
+		Burst:   DefaultBurst,
+	}
+}
+
+// ControllerOptions converts connector options into controller-runtime ones. Rate limiter
+// is the same as the default one, except for the overall rate which is taken from options.
+func (o ConnectorOptions) ControllerOptions() controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: o.Workers,
+		RateLimiter: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(failureBaseDelay, failureMaxDelay),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(o.QPS), o.Burst)},
+		),
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package config
+
+import "strings"
+
+// GetCloudDescription marks cloud resources that have no labels, such as IAM keys, as
+// issued by this cluster for the object with the given name.
+func GetCloudDescription(clusterName, name string) string {
+	return CloudClusterLabel + ":" + clusterName + "\n" + CloudNameLabel + ":" + name
+}
+
+// ParseCloudDescription is the inverse of GetCloudDescription, it fails
+// on descriptions of resources that were not created by connectors.
+func ParseCloudDescription(description string) (clusterName, name string, ok bool) {
+	lines := strings.Split(description, "\n")
+	if len(lines) != 2 {
+		return "", "", false
+	}
+	clusterName, ok1 := cutPrefix(lines[0], CloudClusterLabel+":")
+	name, ok2 := cutPrefix(lines[1], CloudNameLabel+":")
+	return clusterName, name, ok1 && ok2
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(s, prefix), true
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"google.golang.org/grpc/status"
+)
+
+// CloudError is an error of a call to the cloud. It carries the context Yandex Cloud support asks for,
+// which is reported in its message and as structured log fields.
+type CloudError struct {
+	// Operation is the name of the cloud API method, e.g. CreateBucket.
+	Operation string
+	// Resource identifies the resource the call was made for, e.g. registry id or bucket name.
+	Resource string
+	// Code is the gRPC status code or the AWS error code.
+	Code string
+	// RequestID is the id the cloud assigned to the request.
+	RequestID string
+	// ClientRequestID is the id the request was sent with.
+	ClientRequestID string
+	// Details are details of gRPC status.
+	Details []string
+
+	err error
+}
+
+// NewCloudError wraps error of the cloud call, context is extracted from gRPC status or AWS error.
+// It returns nil if err is nil, so that result of the call can be wrapped as is.
+func NewCloudError(err error, operation, resource string) error {
+	if err == nil {
+		return nil
+	}
+
+	res := &CloudError{Operation: operation, Resource: resource, err: err}
+	if s, ok := rpcStatus(err); ok {
+		res.Code = s.Code().String()
+		for _, detail := range s.Details() {
+			res.Details = append(res.Details, fmt.Sprint(detail))
+		}
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		res.Code = awsErr.Code()
+	}
+	var withID interface{ RequestID() string }
+	if errors.As(err, &withID) {
+		res.RequestID = withID.RequestID()
+	}
+	var withClientID interface{ ClientRequestID() string }
+	if errors.As(err, &withClientID) {
+		res.ClientRequestID = withClientID.ClientRequestID()
+	}
+	return res
+}
+
+func (r *CloudError) Error() string {
+	var b strings.Builder
+	b.WriteString(r.Operation)
+	if r.Resource != "" {
+		b.WriteString(" " + r.Resource)
+	}
+	_, _ = fmt.Fprintf(&b, ": %v", r.err)
+
+	var context []string
+	if r.Code != "" {
+		context = append(context, "code: "+r.Code)
+	}
+	if r.RequestID != "" {
+		context = append(context, "request id: "+r.RequestID)
+	}
+	if r.ClientRequestID != "" {
+		context = append(context, "client request id: "+r.ClientRequestID)
+	}
+	if len(context) != 0 {
+		b.WriteString(" (" + strings.Join(context, ", ") + ")")
+	}
+	return b.String()
+}
+
+func (r *CloudError) Unwrap() error {
+	return r.err
+}
+
+// LogFields returns context of the cloud error in the chain as key-value pairs for structured logging.
+func LogFields(err error) []interface{} {
+	var cloudErr *CloudError
+	if !errors.As(err, &cloudErr) {
+		return nil
+	}
+
+	var res []interface{}
+	for _, field := range []struct{ key, value string }{
+		{"operation", cloudErr.Operation},
+		{"resource", cloudErr.Resource},
+		{"code", cloudErr.Code},
+		{"requestId", cloudErr.RequestID},
+		{"clientRequestId", cloudErr.ClientRequestID},
+	} {
+		if field.value != "" {
+			res = append(res, field.key, field.value)
+		}
+	}
+	if len(cloudErr.Details) != 0 {
+		res = append(res, "details", cloudErr.Details)
+	}
+	return res
+}
+
+var requestIDs = regexp.MustCompile(`(client )?request id: [^,)]*`)
+
+// WithoutRequestIDs strips request ids from error message, so that messages of errors
+// which differ only in the request they were caused by can be told equal.
+func WithoutRequestIDs(message string) string {
+	return requestIDs.ReplaceAllString(message, "")
+}
+
+// requestError keeps ids of the gRPC request that failed, it is still recognized as gRPC status.
+type requestError struct {
+	err             error
+	requestID       string
+	clientRequestID string
+}
+
+// WithRequestID attaches ids of the gRPC request to its error, they are picked up by NewCloudError.
+func WithRequestID(err error, requestID, clientRequestID string) error {
+	if err == nil {
+		return nil
+	}
+	return &requestError{err: err, requestID: requestID, clientRequestID: clientRequestID}
+}
+
+func (r *requestError) Error() string {
+	return r.err.Error()
+}
+
+func (r *requestError) Unwrap() error {
+	return r.err
+}
+
+func (r *requestError) GRPCStatus() *status.Status {
+	return status.Convert(r.err)
+}
+
+func (r *requestError) RequestID() string {
+	return r.requestID
+}
+
+func (r *requestError) ClientRequestID() string {
+	return r.clientRequestID
+}
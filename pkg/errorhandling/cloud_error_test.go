@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package errorhandling
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewCloudError(t *testing.T) {
+	t.Run("nil error stays nil", func(t *testing.T) {
+		// Act
+		err := NewCloudError(nil, "GetRegistry", "id")
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("context of gRPC error is kept and error is still recognized", func(t *testing.T) {
+		// Arrange
+		s, err := status.New(codes.NotFound, "registry not found").WithDetails(&errdetails.ResourceInfo{ResourceName: "id"})
+		require.NoError(t, err)
+
+		// Act
+		err = fmt.Errorf("unable to get resource: %w",
+			NewCloudError(WithRequestID(s.Err(), "request-id", "client-request-id"), "GetRegistry", "id"),
+		)
+
+		// Assert
+		assert.True(t, CheckRPCErrorNotFound(err))
+		assert.Contains(t, err.Error(), "GetRegistry id: ")
+		assert.Contains(t, err.Error(), "(code: NotFound, request id: request-id, client request id: client-request-id)")
+		fields := LogFields(err)
+		assert.Subset(t, fields, []interface{}{
+			"operation", "GetRegistry", "resource", "id", "code", "NotFound", "requestId", "request-id",
+		})
+		assert.Contains(t, fields, "details")
+	})
+
+	t.Run("request ids are stripped for comparison", func(t *testing.T) {
+		// Arrange
+		first := NewCloudError(WithRequestID(status.Error(codes.Internal, "oops"), "first", "a"), "GetRegistry", "id")
+		second := NewCloudError(WithRequestID(status.Error(codes.Internal, "oops"), "second", "b"), "GetRegistry", "id")
+
+		// Assert
+		assert.NotEqual(t, first.Error(), second.Error())
+		assert.Equal(t, WithoutRequestIDs(first.Error()), WithoutRequestIDs(second.Error()))
+	})
+}
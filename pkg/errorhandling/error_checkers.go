@@ -31,8 +31,17 @@ DataLoss
 Unauthenticated
 */
 
+// rpcStatus finds gRPC status in the chain of wrapped errors.
+func rpcStatus(err error) (*status.Status, bool) {
+	var s interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &s) {
+		return nil, false
+	}
+	return s.GRPCStatus(), true
+}
+
 func CheckRPCErrorNotFound(err error) bool {
-	s, ok := status.FromError(err)
+	s, ok := rpcStatus(err)
 	if !ok {
 		return false
 	}
@@ -40,7 +49,7 @@ func CheckRPCErrorNotFound(err error) bool {
 }
 
 func CheckRPCErrorAlreadyExists(err error) bool {
-	s, ok := status.FromError(err)
+	s, ok := rpcStatus(err)
 	if !ok {
 		return false
 	}
@@ -22,7 +22,10 @@ func New(message, code string, initial error) ConnectorError {
 }
 
 func (r ConnectorError) Error() string {
-	return fmt.Sprintf("%s: %v", r.message, r.original)
+	if r.original == nil {
+		return fmt.Sprintf("%s [%s]", r.message, r.code)
+	}
+	return fmt.Sprintf("%s [%s]: %v", r.message, r.code, r.original)
 }
 
 func (r ConnectorError) Code() string {
@@ -32,3 +35,7 @@ func (r ConnectorError) Code() string {
 func (r ConnectorError) Initial() error {
 	return r.original
 }
+
+func (r ConnectorError) Unwrap() error {
+	return r.original
+}
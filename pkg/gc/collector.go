@@ -0,0 +1,26 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package gc
+
+import "context"
+
+// Resource is a cloud resource created by a connector of this cluster.
+type Resource struct {
+	// ID of the resource in the cloud.
+	ID string
+	// Name of the custom resource the cloud resource was created for.
+	Name string
+	// Location is where the resource lives in the cloud, e.g. folder or service account,
+	// or the static access key it was found with.
+	Location string
+}
+
+// Collector finds cloud resources of one kind that were created by this cluster
+// but are not backed by any custom resource anymore.
+type Collector interface {
+	// Kind is the short name of the connector the collector belongs to.
+	Kind() string
+	Orphans(ctx context.Context) ([]Resource, error)
+	Delete(ctx context.Context, resource Resource) error
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package gc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	orphanedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "yandex_cloud_connectors_orphaned_resources",
+			Help: "Number of cloud resources created by this cluster without backing custom resource",
+		}, []string{"kind"},
+	)
+	deletedResources = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "yandex_cloud_connectors_orphaned_resources_deleted_total",
+			Help: "Total number of orphaned cloud resources deleted by garbage collector",
+		}, []string{"kind"},
+	)
+	sweepErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "yandex_cloud_connectors_garbage_collection_errors_total",
+			Help: "Total number of failed garbage collector sweeps and deletions",
+		}, []string{"kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphanedResources, deletedResources, sweepErrors)
+}
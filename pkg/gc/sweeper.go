@@ -0,0 +1,138 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	ReasonOrphanFound   = "OrphanedResourceFound"
+	ReasonOrphanDeleted = "OrphanedResourceDeleted"
+	ReasonDeleteFailed  = "OrphanedResourceDeletionFailed"
+)
+
+// Sweeper periodically looks for orphaned cloud resources and reports them with metrics
+// and events of the involved object, usually the manager pod. If deletion is enabled,
+// resources that stay orphaned for the grace period are deleted.
+type Sweeper struct {
+	log         logr.Logger
+	collectors  []Collector
+	recorder    record.EventRecorder
+	involved    *v1.ObjectReference
+	interval    time.Duration
+	gracePeriod time.Duration
+	delete      bool
+
+	now func() time.Time
+	// firstSeen tells since when resources of each kind are known to be orphaned, by their ID
+	firstSeen map[string]map[string]time.Time
+}
+
+func NewSweeper(
+	log logr.Logger,
+	collectors []Collector,
+	recorder record.EventRecorder,
+	involved *v1.ObjectReference,
+	interval, gracePeriod time.Duration,
+	deleteOrphans bool,
+) *Sweeper {
+	return &Sweeper{
+		log:         log,
+		collectors:  collectors,
+		recorder:    recorder,
+		involved:    involved,
+		interval:    interval,
+		gracePeriod: gracePeriod,
+		delete:      deleteOrphans,
+		now:         time.Now,
+		firstSeen:   map[string]map[string]time.Time{},
+	}
+}
+
+// Start implements manager.Runnable.
+func (r *Sweeper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only one
+// replica of the manager should delete resources at a time.
+func (r *Sweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep runs every collector once.
+func (r *Sweeper) Sweep(ctx context.Context) {
+	for _, collector := range r.collectors {
+		r.sweep(ctx, collector)
+	}
+}
+
+func (r *Sweeper) sweep(ctx context.Context, collector Collector) {
+	kind := collector.Kind()
+	log := r.log.WithValues("kind", kind)
+
+	orphans, err := collector.Orphans(ctx)
+	if err != nil {
+		// Grace periods are kept, it is unknown whether resources are still orphaned
+		log.Error(err, "unable to find orphaned resources")
+		sweepErrors.WithLabelValues(kind).Inc()
+		return
+	}
+	orphanedResources.WithLabelValues(kind).Set(float64(len(orphans)))
+
+	// Resources that are not orphaned anymore start their grace period anew
+	previous := r.firstSeen[kind]
+	seen := map[string]time.Time{}
+	r.firstSeen[kind] = seen
+
+	now := r.now()
+	for _, orphan := range orphans {
+		firstSeen, ok := previous[orphan.ID]
+		if !ok {
+			firstSeen = now
+			log.Info("orphaned resource found", "id", orphan.ID, "name", orphan.Name, "location", orphan.Location)
+			r.recorder.Eventf(
+				r.involved, v1.EventTypeWarning, ReasonOrphanFound,
+				"%s %s created for %s in %s has no backing custom resource", kind, orphan.ID, orphan.Name, orphan.Location,
+			)
+		}
+		seen[orphan.ID] = firstSeen
+
+		if !r.delete || now.Sub(firstSeen) < r.gracePeriod {
+			continue
+		}
+		if err := collector.Delete(ctx, orphan); err != nil {
+			log.Error(err, "unable to delete orphaned resource", "id", orphan.ID)
+			sweepErrors.WithLabelValues(kind).Inc()
+			r.recorder.Eventf(
+				r.involved, v1.EventTypeWarning, ReasonDeleteFailed, "unable to delete %s %s: %v", kind, orphan.ID, err,
+			)
+			continue
+		}
+		delete(seen, orphan.ID)
+		deletedResources.WithLabelValues(kind).Inc()
+		log.Info("orphaned resource deleted", "id", orphan.ID)
+		r.recorder.Eventf(
+			r.involved, v1.EventTypeNormal, ReasonOrphanDeleted,
+			"%s %s created for %s in %s is deleted", kind, orphan.ID, orphan.Name, orphan.Location,
+		)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	logrfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/logr-fake"
+)
+
+type fakeCollector struct {
+	orphans []Resource
+	deleted []string
+}
+
+func (r *fakeCollector) Kind() string {
+	return "fake"
+}
+
+func (r *fakeCollector) Orphans(context.Context) ([]Resource, error) {
+	return r.orphans, nil
+}
+
+func (r *fakeCollector) Delete(_ context.Context, resource Resource) error {
+	r.deleted = append(r.deleted, resource.ID)
+	return nil
+}
+
+func setup(t *testing.T, collector Collector, deleteOrphans bool) (*Sweeper, *record.FakeRecorder, *time.Time) {
+	t.Helper()
+	recorder := record.NewFakeRecorder(10)
+	sweeper := NewSweeper(
+		logrfake.NewFakeLogger(t), []Collector{collector}, recorder, &v1.ObjectReference{Kind: "Pod"},
+		time.Hour, time.Hour, deleteOrphans,
+	)
+	now := time.Now()
+	sweeper.now = func() time.Time { return now }
+	return sweeper, recorder, &now
+}
+
+func TestSweep(t *testing.T) {
+	t.Run("orphan is reported once and not deleted by default", func(t *testing.T) {
+		// Arrange
+		collector := &fakeCollector{orphans: []Resource{{ID: "id", Name: "name"}}}
+		sweeper, recorder, now := setup(t, collector, false)
+
+		// Act
+		sweeper.Sweep(context.Background())
+		*now = now.Add(2 * time.Hour)
+		sweeper.Sweep(context.Background())
+
+		// Assert
+		assert.Len(t, recorder.Events, 1)
+		assert.Empty(t, collector.deleted)
+	})
+
+	t.Run("orphan is deleted after grace period", func(t *testing.T) {
+		// Arrange
+		collector := &fakeCollector{orphans: []Resource{{ID: "id", Name: "name"}}}
+		sweeper, _, now := setup(t, collector, true)
+
+		// Act
+		sweeper.Sweep(context.Background())
+		beforeGracePeriod := len(collector.deleted)
+		*now = now.Add(2 * time.Hour)
+		sweeper.Sweep(context.Background())
+
+		// Assert
+		assert.Equal(t, 0, beforeGracePeriod)
+		assert.Equal(t, []string{"id"}, collector.deleted)
+	})
+
+	t.Run("grace period starts anew if resource stops being orphaned", func(t *testing.T) {
+		// Arrange
+		collector := &fakeCollector{orphans: []Resource{{ID: "id", Name: "name"}}}
+		sweeper, _, now := setup(t, collector, true)
+		sweeper.Sweep(context.Background())
+		collector.orphans = nil
+		*now = now.Add(30 * time.Minute)
+		sweeper.Sweep(context.Background())
+		collector.orphans = []Resource{{ID: "id", Name: "name"}}
+		sweeper.Sweep(context.Background())
+
+		// Act
+		*now = now.Add(45 * time.Minute)
+		sweeper.Sweep(context.Background())
+
+		// Assert
+		assert.Empty(t, collector.deleted)
+	})
+}
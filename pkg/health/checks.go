@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	checkTimeout = 5 * time.Second
+	httpsPort    = "443"
+	// tokenTrustPeriod is how long successful acquisition of IAM token is trusted, so that revoked credentials
+	// or unreachable IAM are noticed soon, while probes do not call the cloud every time
+	tokenTrustPeriod = 5 * time.Minute
+)
+
+// IAMTokenCheck verifies that SDK is able to acquire IAM token with its credentials. Successful acquisition
+// is trusted for a few minutes, but never beyond expiration of the token.
+func IAMTokenCheck(sdk *ycsdk.SDK) healthz.Checker {
+	return iamTokenCheck(sdk.CreateIAMToken, time.Now)
+}
+
+func iamTokenCheck(
+	createToken func(ctx context.Context) (*iampb.CreateIamTokenResponse, error), now func() time.Time,
+) healthz.Checker {
+	var mu sync.Mutex
+	var trustedUntil time.Time
+	return func(req *http.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if now().Before(trustedUntil) {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+
+		res, err := createToken(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to acquire IAM token: %w", err)
+		}
+		trustedUntil = now().Add(tokenTrustPeriod)
+		if expiresAt := res.ExpiresAt.AsTime(); expiresAt.Before(trustedUntil) {
+			trustedUntil = expiresAt
+		}
+		return nil
+	}
+}
+
+// EndpointCheck verifies that given cloud endpoint accepts TCP connections.
+// Endpoint is either a host, in which case HTTPS port is assumed, or a host:port pair.
+func EndpointCheck(endpoint string) healthz.Checker {
+	address := endpoint
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		address = net.JoinHostPort(endpoint, httpsPort)
+	}
+
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("unable to reach %s: %w", address, err)
+		}
+		return conn.Close()
+	}
+}
+
+// CertificateCheck verifies that webhook serving certificate and key are present
+// in the directory, match each other and the certificate is currently valid.
+func CertificateCheck(certDir, certName, keyName string) healthz.Checker {
+	return func(_ *http.Request) error {
+		pair, err := tls.LoadX509KeyPair(filepath.Join(certDir, certName), filepath.Join(certDir, keyName))
+		if err != nil {
+			return fmt.Errorf("unable to load webhook certificate: %w", err)
+		}
+
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return fmt.Errorf("unable to parse webhook certificate: %w", err)
+		}
+
+		now := time.Now()
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("webhook certificate is not valid until %s", cert.NotBefore)
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("webhook certificate expired at %s", cert.NotAfter)
+		}
+		return nil
+	}
+}
+
+// CacheSyncCheck verifies that informers of the manager cache are synced.
+func CacheSyncCheck(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer cache is not synced")
+		}
+		return nil
+	}
+}
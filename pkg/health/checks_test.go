@@ -0,0 +1,205 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package health
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func writeCertificate(t *testing.T, dir string, notBefore, notAfter time.Time) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook-service"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "tls.crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		0600,
+	))
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "tls.key"),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		0600,
+	))
+}
+
+func TestIAMTokenCheck(t *testing.T) {
+	t.Run("successful acquisition is trusted for a while", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		calls := 0
+		check := iamTokenCheck(func(context.Context) (*iampb.CreateIamTokenResponse, error) {
+			calls++
+			return &iampb.CreateIamTokenResponse{ExpiresAt: timestamppb.New(now.Add(12 * time.Hour))}, nil
+		}, func() time.Time { return now })
+		req := httptest.NewRequest("GET", "/readyz", nil)
+
+		// Act
+		require.NoError(t, check(req))
+		now = now.Add(tokenTrustPeriod / 2)
+		require.NoError(t, check(req))
+
+		// Assert
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("failure after trust period is reported", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		var failure error
+		check := iamTokenCheck(func(context.Context) (*iampb.CreateIamTokenResponse, error) {
+			if failure != nil {
+				return nil, failure
+			}
+			return &iampb.CreateIamTokenResponse{ExpiresAt: timestamppb.New(now.Add(12 * time.Hour))}, nil
+		}, func() time.Time { return now })
+		req := httptest.NewRequest("GET", "/readyz", nil)
+		require.NoError(t, check(req))
+		failure = fmt.Errorf("key is revoked")
+		now = now.Add(tokenTrustPeriod)
+
+		// Act
+		err := check(req)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("token is not trusted beyond its expiration", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		calls := 0
+		check := iamTokenCheck(func(context.Context) (*iampb.CreateIamTokenResponse, error) {
+			calls++
+			return &iampb.CreateIamTokenResponse{ExpiresAt: timestamppb.New(now.Add(time.Minute))}, nil
+		}, func() time.Time { return now })
+		req := httptest.NewRequest("GET", "/readyz", nil)
+
+		// Act
+		require.NoError(t, check(req))
+		now = now.Add(time.Minute)
+		require.NoError(t, check(req))
+
+		// Assert
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("failed acquisition is retried on next probe", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		check := iamTokenCheck(func(context.Context) (*iampb.CreateIamTokenResponse, error) {
+			calls++
+			return nil, fmt.Errorf("permission denied")
+		}, time.Now)
+		req := httptest.NewRequest("GET", "/readyz", nil)
+
+		// Act
+		err1 := check(req)
+		err2 := check(req)
+
+		// Assert
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestCertificateCheck(t *testing.T) {
+	t.Run("valid certificate passes", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeCertificate(t, dir, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+		// Act
+		err := CertificateCheck(dir, "tls.crt", "tls.key")(httptest.NewRequest("GET", "/readyz", nil))
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired certificate fails", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeCertificate(t, dir, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+
+		// Act
+		err := CertificateCheck(dir, "tls.crt", "tls.key")(httptest.NewRequest("GET", "/readyz", nil))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("missing certificate fails", func(t *testing.T) {
+		// Act
+		err := CertificateCheck(t.TempDir(), "tls.crt", "tls.key")(httptest.NewRequest("GET", "/readyz", nil))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestEndpointCheck(t *testing.T) {
+	t.Run("listening endpoint passes", func(t *testing.T) {
+		// Arrange
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = listener.Close() }()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				_ = conn.Close()
+			}
+		}()
+
+		// Act
+		err = EndpointCheck(listener.Addr().String())(httptest.NewRequest("GET", "/readyz", nil))
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("closed endpoint fails", func(t *testing.T) {
+		// Arrange
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		// Act
+		err = EndpointCheck(address)(httptest.NewRequest("GET", "/readyz", nil))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package managerconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/clusterid"
+
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	authkeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/config"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const (
+	APIVersion = "connectors.cloud.yandex.com/v1alpha1"
+	Kind       = "ManagerConfig"
+)
+
+// KnownConnectors are short names of all connectors the manager is able to run.
+var KnownConnectors = []string{
+	apikeyconfig.ShortName,
+	authkeyconfig.ShortName,
+	sakeyconfig.ShortName,
+	ycrconfig.ShortName,
+	ymqconfig.ShortName,
+	yosconfig.ShortName,
+	ysaconfig.ShortName,
+}
+
+// ManagerConfig is the configuration file of the connector manager.
+type ManagerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Metrics        MetricsConfig        `json:"metrics"`
+	Health         HealthConfig         `json:"health"`
+	LeaderElection LeaderElectionConfig `json:"leaderElection"`
+	Webhook        WebhookConfig        `json:"webhook"`
+	ClusterID      ClusterIDConfig      `json:"clusterId"`
+	// WatchNamespaces restricts the manager to given namespaces, all namespaces are watched if empty.
+	WatchNamespaces []string                   `json:"watchNamespaces,omitempty"`
+	Requeue         RequeueConfig              `json:"requeue"`
+	Endpoints       EndpointsConfig            `json:"endpoints"`
+	Connectors      map[string]ConnectorConfig `json:"connectors,omitempty"`
+	// GarbageCollection looks for cloud resources of this cluster that are left without custom resources.
+	GarbageCollection GarbageCollectionConfig `json:"garbageCollection"`
+	Tracing           TracingConfig           `json:"tracing"`
+}
+
+type MetricsConfig struct {
+	BindAddress string `json:"bindAddress"`
+}
+
+type HealthConfig struct {
+	BindAddress string `json:"bindAddress"`
+}
+
+type LeaderElectionConfig struct {
+	Enabled       bool            `json:"enabled"`
+	ID            string          `json:"id"`
+	LeaseDuration metav1.Duration `json:"leaseDuration"`
+	RenewDeadline metav1.Duration `json:"renewDeadline"`
+	RetryPeriod   metav1.Duration `json:"retryPeriod"`
+}
+
+type WebhookConfig struct {
+	Port        int                      `json:"port"`
+	CertDir     string                   `json:"certDir"`
+	Certificate CertificateRotatorConfig `json:"certificate"`
+}
+
+type CertificateRotatorConfig struct {
+	Rotate                   bool            `json:"rotate"`
+	Namespace                string          `json:"namespace"`
+	Service                  string          `json:"service"`
+	Secret                   string          `json:"secret"`
+	MutatingConfigurations   []string        `json:"mutatingConfigurations,omitempty"`
+	ValidatingConfigurations []string        `json:"validatingConfigurations,omitempty"`
+	RenewBefore              metav1.Duration `json:"renewBefore"`
+	CheckInterval            metav1.Duration `json:"checkInterval"`
+}
+
+const (
+	ClusterIDSourceStatic    = "static"
+	ClusterIDSourceMetadata  = "metadata"
+	ClusterIDSourceNamespace = "namespace"
+	ClusterIDSourceConfigMap = "configmap"
+)
+
+// ClusterIDConfig tells where identity of the cluster used in ownership labels is taken from.
+type ClusterIDConfig struct {
+	// Source is one of static, metadata, namespace or configmap.
+	Source string `json:"source"`
+	// Value is the identity itself for static source.
+	Value string `json:"value,omitempty"`
+	// MetadataEndpoint is the address of instance metadata service for metadata source.
+	MetadataEndpoint string `json:"metadataEndpoint"`
+	// Namespace is the namespace which UID is used for namespace source.
+	Namespace string `json:"namespace"`
+	// ConfigMap is the location of identity for configmap source.
+	ConfigMap ConfigMapKeyConfig `json:"configMap,omitempty"`
+}
+
+type ConfigMapKeyConfig struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Key       string `json:"key,omitempty"`
+}
+
+type RequeueConfig struct {
+	Normal  metav1.Duration `json:"normal"`
+	Errored metav1.Duration `json:"errored"`
+}
+
+type EndpointsConfig struct {
+	MessageQueue  string `json:"messageQueue"`
+	ObjectStorage string `json:"objectStorage"`
+}
+
+// GarbageCollectionConfig configures sweeper of orphaned cloud resources.
+type GarbageCollectionConfig struct {
+	Enabled  bool            `json:"enabled"`
+	Interval metav1.Duration `json:"interval"`
+	// Delete enables deletion of resources that stay orphaned for GracePeriod, they are only reported otherwise.
+	Delete      bool            `json:"delete"`
+	GracePeriod metav1.Duration `json:"gracePeriod"`
+	// Folders are looked through for registries in addition to folders of existing objects.
+	Folders []string `json:"folders,omitempty"`
+	// ServiceAccounts are looked through for keys in addition to service accounts of existing objects.
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+	// StaticAccessKeys are used to list queues and buckets in addition to keys of existing objects.
+	StaticAccessKeys []ObjectKeyConfig `json:"staticAccessKeys,omitempty"`
+}
+
+type ObjectKeyConfig struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// StaticAccessKeyNames returns names of StaticAccessKeys used to list queues and buckets.
+func (c *GarbageCollectionConfig) StaticAccessKeyNames() []types.NamespacedName {
+	var res []types.NamespacedName
+	for _, key := range c.StaticAccessKeys {
+		res = append(res, types.NamespacedName{Namespace: key.Namespace, Name: key.Name})
+	}
+	return res
+}
+
+// TracingConfig configures export of reconciliation traces over OTLP, traces are not exported if Endpoint is empty.
+type TracingConfig struct {
+	// Endpoint is the address of OTLP gRPC collector, e.g. otel-collector:4317.
+	Endpoint string `json:"endpoint,omitempty"`
+	Insecure bool   `json:"insecure"`
+	// SampleRatio is the share of reconciliations that are traced.
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+type ConnectorConfig struct {
+	// Enabled defaults to true, connector is turned off only if it is explicitly set to false.
+	Enabled *bool   `json:"enabled,omitempty"`
+	Workers int     `json:"workers,omitempty"`
+	QPS     float64 `json:"qps,omitempty"`
+	Burst   int     `json:"burst,omitempty"`
+}
+
+// IsEnabled reports whether connector with the given short name must be started.
+func (c *ManagerConfig) IsEnabled(name string) bool {
+	connector, ok := c.Connectors[name]
+	return ok && connector.Enabled != nil && *connector.Enabled
+}
+
+// EnabledConnectors returns short names of the connectors that must be started.
+func (c *ManagerConfig) EnabledConnectors() []string {
+	var res []string
+	for _, name := range KnownConnectors {
+		if c.IsEnabled(name) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// Options returns controller options of the connector with the given short name.
+func (c *ManagerConfig) Options(name string) config.ConnectorOptions {
+	connector := c.Connectors[name]
+	return config.ConnectorOptions{
+		Workers: connector.Workers,
+		QPS:     connector.QPS,
+		Burst:   connector.Burst,
+	}
+}
+
+// Connector returns settings of the connector with the given short name,
+// connectors missing from the configuration are disabled and have default settings.
+func (c *ManagerConfig) Connector(name string) ConnectorConfig {
+	if connector, ok := c.Connectors[name]; ok {
+		return connector
+	}
+
+	defaults := config.DefaultConnectorOptions()
+	enabled := false
+	return ConnectorConfig{
+		Enabled: &enabled,
+		Workers: defaults.Workers,
+		QPS:     defaults.QPS,
+		Burst:   defaults.Burst,
+	}
+}
+
+// Default returns configuration the manager runs with if no file is given.
+func Default() *ManagerConfig {
+	connectors := map[string]ConnectorConfig{}
+	for _, name := range KnownConnectors {
+		connectors[name] = ConnectorConfig{}
+	}
+
+	cfg := &ManagerConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       Kind,
+		},
+		Metrics: MetricsConfig{BindAddress: ":8080"},
+		Health:  HealthConfig{BindAddress: ":8081"},
+		LeaderElection: LeaderElectionConfig{
+			Enabled:       false,
+			ID:            "faeacf9e.cloud.yandex.com",
+			LeaseDuration: metav1.Duration{Duration: 15 * time.Second},
+			RenewDeadline: metav1.Duration{Duration: 10 * time.Second},
+			RetryPeriod:   metav1.Duration{Duration: 2 * time.Second},
+		},
+		Webhook: WebhookConfig{
+			Port:    9443,
+			CertDir: "/etc/yandex-cloud-connectors/certs",
+			Certificate: CertificateRotatorConfig{
+				Rotate:        false,
+				Namespace:     "default",
+				Service:       "webhook-service",
+				Secret:        "webhook-tls-cert",
+				RenewBefore:   metav1.Duration{Duration: 30 * 24 * time.Hour},
+				CheckInterval: metav1.Duration{Duration: time.Hour},
+			},
+		},
+		ClusterID: ClusterIDConfig{
+			Source:           ClusterIDSourceMetadata,
+			MetadataEndpoint: clusterid.DefaultMetadataEndpoint,
+			Namespace:        clusterid.DefaultNamespace,
+			ConfigMap:        ConfigMapKeyConfig{Key: "cluster-id"},
+		},
+		Requeue: RequeueConfig{
+			Normal:  metav1.Duration{Duration: config.DefaultNormalRequeue},
+			Errored: metav1.Duration{Duration: config.DefaultErroredRequeue},
+		},
+		Endpoints: EndpointsConfig{
+			MessageQueue:  ymqconfig.DefaultEndpoint,
+			ObjectStorage: yosconfig.DefaultEndpoint,
+		},
+		Connectors: connectors,
+		GarbageCollection: GarbageCollectionConfig{
+			Enabled:     false,
+			Interval:    metav1.Duration{Duration: time.Hour},
+			Delete:      false,
+			GracePeriod: metav1.Duration{Duration: 24 * time.Hour},
+		},
+		Tracing: TracingConfig{SampleRatio: 1},
+	}
+	cfg.setConnectorDefaults()
+	return cfg
+}
+
+// Load reads configuration file, every value missing from the file is taken from Default.
+// Unknown and duplicate fields are rejected.
+func Load(path string) (*ManagerConfig, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes configuration from its YAML representation, see Load.
+func Parse(data []byte) (*ManagerConfig, error) {
+	cfg := Default()
+	// Version must be stated explicitly, while connectors of the file are merged over the default ones
+	cfg.TypeMeta = metav1.TypeMeta{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode config: %w", err)
+	}
+
+	if cfg.APIVersion != APIVersion || cfg.Kind != Kind {
+		return nil, fmt.Errorf(
+			"unsupported config version %s, %s; expected %s, %s", cfg.APIVersion, cfg.Kind, APIVersion, Kind,
+		)
+	}
+
+	cfg.setConnectorDefaults()
+	return cfg, nil
+}
+
+func (c *ManagerConfig) setConnectorDefaults() {
+	defaults := config.DefaultConnectorOptions()
+	for name, connector := range c.Connectors {
+		if connector.Enabled == nil {
+			enabled := true
+			connector.Enabled = &enabled
+		}
+		if connector.Workers == 0 {
+			connector.Workers = defaults.Workers
+		}
+		if connector.QPS == 0 {
+			connector.QPS = defaults.QPS
+		}
+		if connector.Burst == 0 {
+			connector.Burst = defaults.Burst
+		}
+		c.Connectors[name] = connector
+	}
+}
+
+// SetEnabledConnectors enables exactly the given connectors, keeping their other settings.
+func (c *ManagerConfig) SetEnabledConnectors(names []string) {
+	for _, name := range names {
+		if _, ok := c.Connectors[name]; !ok {
+			c.Connectors[name] = ConnectorConfig{}
+		}
+	}
+	c.setConnectorDefaults()
+
+	for name, connector := range c.Connectors {
+		enabled := util.ContainsString(names, name)
+		connector.Enabled = &enabled
+		c.Connectors[name] = connector
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package managerconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	t.Run("default config is valid and enables all connectors", func(t *testing.T) {
+		// Act
+		cfg := Default()
+
+		// Assert
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, KnownConnectors, cfg.EnabledConnectors())
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("values missing from file are defaulted", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+apiVersion: connectors.cloud.yandex.com/v1alpha1
+kind: ManagerConfig
+webhook:
+  port: 10443
+requeue:
+  normal: 5m
+connectors:
+  sakey:
+    workers: 4
+  ycr: {}
+`)
+
+		// Act
+		cfg, err := Parse(data)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, 10443, cfg.Webhook.Port)
+		assert.Equal(t, Default().Webhook.CertDir, cfg.Webhook.CertDir)
+		assert.Equal(t, 5*time.Minute, cfg.Requeue.Normal.Duration)
+		assert.Equal(t, Default().Requeue.Errored, cfg.Requeue.Errored)
+		assert.Equal(t, KnownConnectors, cfg.EnabledConnectors())
+		assert.Equal(t, 4, cfg.Options("sakey").Workers)
+		assert.Equal(t, Default().Options("sakey").QPS, cfg.Options("sakey").QPS)
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+apiVersion: connectors.cloud.yandex.com/v1alpha1
+kind: ManagerConfig
+webhook:
+  prot: 10443
+`)
+
+		// Act
+		_, err := Parse(data)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("missing version is rejected", func(t *testing.T) {
+		// Act
+		_, err := Parse([]byte("webhook:\n  port: 10443\n"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("connector can be disabled explicitly", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+apiVersion: connectors.cloud.yandex.com/v1alpha1
+kind: ManagerConfig
+connectors:
+  sakey: {}
+  ymq:
+    enabled: false
+`)
+
+		// Act
+		cfg, err := Parse(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotContains(t, cfg.EnabledConnectors(), "ymq")
+		assert.Len(t, cfg.EnabledConnectors(), len(KnownConnectors)-1)
+	})
+
+	t.Run("per-connector settings keep every connector enabled", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+apiVersion: connectors.cloud.yandex.com/v1alpha1
+kind: ManagerConfig
+connectors:
+  sakey:
+    workers: 4
+`)
+
+		// Act
+		cfg, err := Parse(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, KnownConnectors, cfg.EnabledConnectors())
+		assert.Equal(t, 4, cfg.Options("sakey").Workers)
+		assert.Equal(t, Default().Options("ycr"), cfg.Options("ycr"))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("unknown connector is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.SetEnabledConnectors([]string{"sakey", "unknown"})
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("inconsistent leader election timings are invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.LeaderElection.Enabled = true
+		cfg.LeaderElection.RenewDeadline = cfg.LeaderElection.LeaseDuration
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("tracing sample ratio out of range is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.Tracing.SampleRatio = 1.5
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("static cluster id without value is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.ClusterID.Source = ClusterIDSourceStatic
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown cluster id source is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.ClusterID.Source = "unknown"
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("garbage collection with watched namespaces is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.GarbageCollection.Enabled = true
+		cfg.WatchNamespaces = []string{"default"}
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("garbage collection key without namespace is invalid", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		cfg.GarbageCollection.Enabled = true
+		cfg.GarbageCollection.StaticAccessKeys = []ObjectKeyConfig{{Name: "sakey"}}
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("enabling subset of connectors keeps their settings", func(t *testing.T) {
+		// Arrange
+		cfg := Default()
+		sakey := cfg.Connectors["sakey"]
+		sakey.Workers = 3
+		cfg.Connectors["sakey"] = sakey
+
+		// Act
+		cfg.SetEnabledConnectors([]string{"sakey"})
+
+		// Assert
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, []string{"sakey"}, cfg.EnabledConnectors())
+		assert.Equal(t, 3, cfg.Options("sakey").Workers)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package managerconfig
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const maxPort = 65535
+
+// Validate checks that configuration is consistent, all found problems are reported at once.
+func (c *ManagerConfig) Validate() error {
+	var err error
+
+	if c.Webhook.Port <= 0 || c.Webhook.Port > maxPort {
+		err = multierr.Append(err, fmt.Errorf("webhook port must be in range 1-%d, got %d", maxPort, c.Webhook.Port))
+	}
+	if c.Webhook.CertDir == "" {
+		err = multierr.Append(err, fmt.Errorf("webhook cert dir must not be empty"))
+	}
+	if c.Webhook.Certificate.Rotate {
+		err = multierr.Append(err, c.Webhook.Certificate.validate())
+	}
+
+	err = multierr.Append(err, c.LeaderElection.validate())
+	err = multierr.Append(err, c.ClusterID.validate())
+
+	if c.Requeue.Normal.Duration <= 0 || c.Requeue.Errored.Duration <= 0 {
+		err = multierr.Append(err, fmt.Errorf("requeue intervals must be positive"))
+	}
+
+	if c.Endpoints.MessageQueue == "" || c.Endpoints.ObjectStorage == "" {
+		err = multierr.Append(err, fmt.Errorf("endpoints must not be empty"))
+	}
+
+	for _, ns := range c.WatchNamespaces {
+		if ns == "" {
+			err = multierr.Append(err, fmt.Errorf("watched namespace name must not be empty"))
+		}
+	}
+
+	for name, connector := range c.Connectors {
+		if !util.ContainsString(KnownConnectors, name) {
+			err = multierr.Append(err, fmt.Errorf("unknown connector: %s", name))
+			continue
+		}
+		if connector.Workers < 1 || connector.QPS <= 0 || connector.Burst < 1 {
+			err = multierr.Append(err, fmt.Errorf("workers, qps and burst of connector %s must be positive", name))
+		}
+	}
+	if c.GarbageCollection.Enabled {
+		err = multierr.Append(err, c.GarbageCollection.validate(c.WatchNamespaces))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		err = multierr.Append(err, fmt.Errorf("tracing sample ratio must be in range 0-1, got %v", c.Tracing.SampleRatio))
+	}
+
+	if len(c.EnabledConnectors()) == 0 {
+		err = multierr.Append(err, fmt.Errorf("at least one connector must be enabled"))
+	}
+
+	return err
+}
+
+func (c *LeaderElectionConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ID == "" {
+		return fmt.Errorf("leader election id must not be empty")
+	}
+	if c.RetryPeriod.Duration <= 0 ||
+		c.RenewDeadline.Duration <= c.RetryPeriod.Duration ||
+		c.LeaseDuration.Duration <= c.RenewDeadline.Duration {
+		return fmt.Errorf("leader election timings must satisfy leaseDuration > renewDeadline > retryPeriod > 0")
+	}
+	return nil
+}
+
+func (c *CertificateRotatorConfig) validate() error {
+	if c.Namespace == "" || c.Service == "" || c.Secret == "" {
+		return fmt.Errorf("webhook certificate namespace, service and secret must not be empty")
+	}
+	if c.RenewBefore.Duration <= 0 || c.CheckInterval.Duration <= 0 {
+		return fmt.Errorf("webhook certificate renewal intervals must be positive")
+	}
+	return nil
+}
+
+func (c *ClusterIDConfig) validate() error {
+	switch c.Source {
+	case ClusterIDSourceStatic:
+		if c.Value == "" {
+			return fmt.Errorf("cluster id value must be set for static source")
+		}
+	case ClusterIDSourceMetadata:
+		if c.MetadataEndpoint == "" {
+			return fmt.Errorf("metadata endpoint must be set for metadata cluster id source")
+		}
+	case ClusterIDSourceNamespace:
+		if c.Namespace == "" {
+			return fmt.Errorf("namespace must be set for namespace cluster id source")
+		}
+	case ClusterIDSourceConfigMap:
+		if c.ConfigMap.Namespace == "" || c.ConfigMap.Name == "" || c.ConfigMap.Key == "" {
+			return fmt.Errorf("configmap namespace, name and key must be set for configmap cluster id source")
+		}
+	default:
+		return fmt.Errorf("unknown cluster id source: %s", c.Source)
+	}
+	return nil
+}
+
+func (c *GarbageCollectionConfig) validate(watchNamespaces []string) error {
+	// Objects from unwatched namespaces are invisible, their resources would look orphaned
+	if len(watchNamespaces) != 0 {
+		return fmt.Errorf("garbage collection requires all namespaces to be watched")
+	}
+	if c.Interval.Duration <= 0 || c.GracePeriod.Duration < 0 {
+		return fmt.Errorf("garbage collection interval must be positive and grace period must not be negative")
+	}
+	for _, key := range c.StaticAccessKeys {
+		if key.Namespace == "" || key.Name == "" {
+			return fmt.Errorf("namespace and name must be set for static access keys of garbage collection")
+		}
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/configmap"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 )
 
 func ProvideConfigmap(
@@ -19,7 +20,10 @@ func ProvideConfigmap(
 	log logr.Logger,
 	objectName, kindName, namespace string,
 	contents map[string]string,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "provide-configmap")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	exists, err := configmap.Exists(ctx, cl, objectName, namespace, kindName)
@@ -43,7 +47,10 @@ func RemoveConfigmap(
 	cl client.Client,
 	log logr.Logger,
 	objectName, kindName, namespace string,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "remove-configmap")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 
 	if err := configmap.Remove(ctx, cl, objectName, namespace, kindName); err != nil {
@@ -0,0 +1,80 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const (
+	ReasonCleanupSkipped = "CleanupSkipped"
+	ReasonCleanupFailed  = "CleanupFailed"
+)
+
+type cleanupImpossibleError struct {
+	err error
+}
+
+func (e *cleanupImpossibleError) Error() string {
+	return "cloud cleanup is impossible: " + e.err.Error()
+}
+
+func (e *cleanupImpossibleError) Unwrap() error {
+	return e.err
+}
+
+// CleanupImpossible marks error of deallocate after which cleanup can never succeed, e.g. because
+// credentials needed for it are gone. Such object is finalized as if it was orphaned.
+func CleanupImpossible(err error) error {
+	return &cleanupImpossibleError{err: err}
+}
+
+// CleanupBypassed reports whether object is orphaned or force-deleted, so that its deletion
+// must not be refused for the sake of its cloud resource.
+func CleanupBypassed(object client.Object) bool {
+	return util.AnnotationIsSet(object, config.OrphanAnnotation) ||
+		util.AnnotationIsSet(object, config.ForceDeleteAnnotation)
+}
+
+// Deallocate calls deallocate unless the object is orphaned. Errors of deallocate are
+// tolerated if the object is force-deleted or cleanup is impossible. Every skipped cleanup is recorded as an event.
+func Deallocate(log logr.Logger, recorder record.EventRecorder, object client.Object, deallocate func() error) error {
+	if util.AnnotationIsSet(object, config.OrphanAnnotation) {
+		log.Info("resource is orphaned, cloud cleanup skipped")
+		recorder.Event(
+			object, v1.EventTypeWarning, ReasonCleanupSkipped,
+			"cloud resource is left as is because of "+config.OrphanAnnotation+" annotation",
+		)
+		return nil
+	}
+
+	err := deallocate()
+	var impossible *cleanupImpossibleError
+	if errors.As(err, &impossible) {
+		log.Error(err, "cloud cleanup is impossible, resource is orphaned")
+		recorder.Eventf(
+			object, v1.EventTypeWarning, ReasonCleanupSkipped,
+			"cloud resource is left as is, as it cannot be cleaned up: %v", impossible.err,
+		)
+		return nil
+	}
+	if err == nil || !util.AnnotationIsSet(object, config.ForceDeleteAnnotation) {
+		return err
+	}
+
+	log.Error(err, "resource is force-deleted, cloud cleanup failure ignored")
+	recorder.Eventf(
+		object, v1.EventTypeWarning, ReasonCleanupFailed,
+		"cloud cleanup failed and is skipped because of %s annotation: %v", config.ForceDeleteAnnotation, err,
+	)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+func annotatedObject(annotations map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "object", Namespace: "default", Annotations: annotations},
+	}
+}
+
+func TestDeallocate(t *testing.T) {
+	t.Run("failure is returned without annotations", func(t *testing.T) {
+		// Arrange
+		_, log, _ := setup(t)
+		recorder := record.NewFakeRecorder(1)
+
+		// Act
+		err := Deallocate(log, recorder, annotatedObject(nil), func() error { return fmt.Errorf("failure") })
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("orphaned object is not deallocated", func(t *testing.T) {
+		// Arrange
+		_, log, _ := setup(t)
+		recorder := record.NewFakeRecorder(1)
+		called := false
+
+		// Act
+		err := Deallocate(
+			log, recorder, annotatedObject(map[string]string{config.OrphanAnnotation: "true"}),
+			func() error { called = true; return nil },
+		)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, called)
+		assert.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("failure is tolerated for force-deleted object", func(t *testing.T) {
+		// Arrange
+		_, log, _ := setup(t)
+		recorder := record.NewFakeRecorder(1)
+
+		// Act
+		err := Deallocate(
+			log, recorder, annotatedObject(map[string]string{config.ForceDeleteAnnotation: "true"}),
+			func() error { return fmt.Errorf("failure") },
+		)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("annotation set to false is ignored", func(t *testing.T) {
+		// Arrange
+		_, log, _ := setup(t)
+		recorder := record.NewFakeRecorder(1)
+
+		// Act
+		err := Deallocate(
+			log, recorder, annotatedObject(map[string]string{config.ForceDeleteAnnotation: "false"}),
+			func() error { return fmt.Errorf("failure") },
+		)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("impossible cleanup is skipped", func(t *testing.T) {
+		// Arrange
+		_, log, _ := setup(t)
+		recorder := record.NewFakeRecorder(1)
+
+		// Act
+		err := Deallocate(log, recorder, annotatedObject(nil), func() error {
+			return fmt.Errorf("unable to build sdk: %w", CleanupImpossible(fmt.Errorf("key is gone")))
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, recorder.Events, 1)
+	})
+}
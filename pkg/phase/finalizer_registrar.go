@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/tracing"
 	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
 )
 
@@ -21,7 +22,10 @@ func MustBeFinalized(meta *metav1.ObjectMeta, finalizer string) bool {
 func RegisterFinalizer(
 	ctx context.Context, cl client.Client, log logr.Logger, meta *metav1.ObjectMeta, object client.Object,
 	finalizer string,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "register-finalizer")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 	if util.ContainsString(meta.Finalizers, finalizer) {
 		return nil
@@ -37,7 +41,10 @@ func RegisterFinalizer(
 func DeregisterFinalizer(
 	ctx context.Context, cl client.Client, log logr.Logger, meta *metav1.ObjectMeta, object client.Object,
 	finalizer string,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "deregister-finalizer")
+	defer func() { tracing.End(span, err) }()
+
 	log.V(1).Info("started")
 	meta.Finalizers = util.RemoveString(meta.Finalizers, finalizer)
 	if err := cl.Update(ctx, object); err != nil {
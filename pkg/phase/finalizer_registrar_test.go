@@ -8,6 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -64,6 +68,28 @@ func TestRegisterFinalizer(t *testing.T) {
 			assert.Contains(t, res.Finalizers, "ugly")
 		},
 	)
+
+	t.Run(
+		"failed register records error in its span", func(t *testing.T) {
+			// Arrange
+			ctx, log, cl := setup(t)
+			recorder := tracetest.NewSpanRecorder()
+			previous := otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			t.Cleanup(func() { otel.SetTracerProvider(previous) })
+			object := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}
+
+			// Act
+			err := RegisterFinalizer(ctx, cl, log, &object.ObjectMeta, &object, "good")
+
+			// Assert
+			require.Error(t, err)
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "register-finalizer", spans[0].Name())
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		},
+	)
 }
 
 func TestDeregisterFinalizer(t *testing.T) {
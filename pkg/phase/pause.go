@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const (
+	ConditionPaused = "Paused"
+
+	reasonPausedByAnnotation = "PausedByAnnotation"
+	reasonResumed            = "Resumed"
+)
+
+// UpdateFunc persists object, it is either Update of the client or Update of its status writer.
+type UpdateFunc func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error
+
+// SyncPaused reports whether reconciliation of the object is paused with annotation and reflects
+// it in Paused condition. Conditions are persisted with update only if they have changed.
+func SyncPaused(
+	ctx context.Context,
+	log logr.Logger,
+	object client.Object,
+	conditions *[]metav1.Condition,
+	update UpdateFunc,
+) (bool, error) {
+	paused := util.AnnotationIsSet(object, config.PausedAnnotation)
+
+	current := meta.FindStatusCondition(*conditions, ConditionPaused)
+	if current == nil && !paused || current != nil && current.Status == conditionStatus(paused) {
+		return paused, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionPaused,
+		Status:             conditionStatus(paused),
+		ObservedGeneration: object.GetGeneration(),
+		Reason:             reasonResumed,
+		Message:            "reconciliation is resumed",
+	}
+	if paused {
+		condition.Reason = reasonPausedByAnnotation
+		condition.Message = "reconciliation is paused with " + config.PausedAnnotation + " annotation"
+	}
+	meta.SetStatusCondition(conditions, condition)
+
+	if err := update(ctx, object); err != nil {
+		return paused, fmt.Errorf("unable to update paused condition: %w", err)
+	}
+	log.Info("paused condition updated", "paused", paused)
+	return paused, nil
+}
+
+func conditionStatus(value bool) metav1.ConditionStatus {
+	if value {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+)
+
+type countingUpdate int
+
+func (r *countingUpdate) update(context.Context, client.Object, ...client.UpdateOption) error {
+	*r++
+	return nil
+}
+
+func TestSyncPaused(t *testing.T) {
+	t.Run("object without annotation is not paused and not updated", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		paused, err := SyncPaused(ctx, log, annotatedObject(nil), &conditions, updates.update)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.Empty(t, conditions)
+		assert.Equal(t, countingUpdate(0), updates)
+	})
+
+	t.Run("annotated object is paused once", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		object := annotatedObject(map[string]string{config.PausedAnnotation: "true"})
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		paused, err := SyncPaused(ctx, log, object, &conditions, updates.update)
+		require.NoError(t, err)
+		_, err = SyncPaused(ctx, log, object, &conditions, updates.update)
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, paused)
+		assert.True(t, meta.IsStatusConditionTrue(conditions, ConditionPaused))
+		assert.Equal(t, countingUpdate(1), updates)
+	})
+
+	t.Run("removing annotation resumes object", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+		_, err := SyncPaused(
+			ctx, log, annotatedObject(map[string]string{config.PausedAnnotation: "true"}), &conditions, updates.update,
+		)
+		require.NoError(t, err)
+
+		// Act
+		paused, err := SyncPaused(ctx, log, annotatedObject(nil), &conditions, updates.update)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.True(t, meta.IsStatusConditionFalse(conditions, ConditionPaused))
+		assert.Equal(t, countingUpdate(2), updates)
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+const (
+	ConditionReady = "Ready"
+
+	reasonReconciled     = "Reconciled"
+	reasonCloudError     = "CloudError"
+	reasonReconcileError = "ReconcileError"
+
+	// maxConditionMessage is the limit of condition message length imposed by API server.
+	maxConditionMessage = 32768
+)
+
+// SyncReady reflects outcome of the reconciliation in Ready condition. If reconciliation failed, its
+// error is logged with context of the cloud call it was caused by, and is returned as is. Conditions
+// are persisted with update only if they have changed, messages of errors that differ only in request
+// ids are considered equal, so that update does not trigger reconciliation of the failing object again.
+// Observed generation is not compared, as status update bumps generation of objects without status subresource.
+func SyncReady(
+	ctx context.Context,
+	log logr.Logger,
+	object client.Object,
+	conditions *[]metav1.Condition,
+	update UpdateFunc,
+	err error,
+) error {
+	condition := metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: object.GetGeneration(),
+		Reason:             reasonReconciled,
+		Message:            "resource is reconciled",
+	}
+	if err != nil {
+		log.Error(err, "reconciliation failed", errorhandling.LogFields(err)...)
+
+		var cloudErr *errorhandling.CloudError
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonReconcileError
+		if errors.As(err, &cloudErr) {
+			condition.Reason = reasonCloudError
+		}
+		condition.Message = err.Error()
+		if len(condition.Message) > maxConditionMessage {
+			condition.Message = condition.Message[:maxConditionMessage]
+		}
+	}
+
+	if current := meta.FindStatusCondition(*conditions, ConditionReady); current != nil &&
+		current.Status == condition.Status &&
+		current.Reason == condition.Reason &&
+		errorhandling.WithoutRequestIDs(current.Message) == errorhandling.WithoutRequestIDs(condition.Message) {
+		return err
+	}
+	meta.SetStatusCondition(conditions, condition)
+
+	if updateErr := update(ctx, object); updateErr != nil {
+		if err != nil {
+			log.Error(updateErr, "unable to update ready condition")
+			return err
+		}
+		return fmt.Errorf("unable to update ready condition: %w", updateErr)
+	}
+	log.V(1).Info("ready condition updated", "ready", err == nil)
+	return err
+}
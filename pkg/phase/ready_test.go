@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+func cloudError(requestID string) error {
+	return fmt.Errorf("unable to allocate resource: %w", errorhandling.NewCloudError(
+		awserr.NewRequestFailure(awserr.New("AccessDenied", "access denied", nil), 403, requestID),
+		"CreateBucket", "bucket",
+	))
+}
+
+func TestSyncReady(t *testing.T) {
+	t.Run("successful reconciliation makes object ready once", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		object := annotatedObject(nil)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		require.NoError(t, SyncReady(ctx, log, object, &conditions, updates.update, nil))
+		require.NoError(t, SyncReady(ctx, log, object, &conditions, updates.update, nil))
+
+		// Assert
+		assert.True(t, meta.IsStatusConditionTrue(conditions, ConditionReady))
+		assert.Equal(t, countingUpdate(1), updates)
+	})
+
+	t.Run("cloud error is returned and reflected in condition with request id", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		err := SyncReady(ctx, log, annotatedObject(nil), &conditions, updates.update, cloudError("request-id"))
+
+		// Assert
+		require.Error(t, err)
+		condition := meta.FindStatusCondition(conditions, ConditionReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, reasonCloudError, condition.Reason)
+		assert.Contains(t, condition.Message, "CreateBucket bucket")
+		assert.Contains(t, condition.Message, "code: AccessDenied")
+		assert.Contains(t, condition.Message, "request id: request-id")
+	})
+
+	t.Run("error repeated with another request id does not update object", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		object := annotatedObject(nil)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+		require.Error(t, SyncReady(ctx, log, object, &conditions, updates.update, cloudError("first")))
+
+		// Act
+		err := SyncReady(ctx, log, object, &conditions, updates.update, cloudError("second"))
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, countingUpdate(1), updates)
+		assert.Contains(t, meta.FindStatusCondition(conditions, ConditionReady).Message, "request id: first")
+	})
+
+	t.Run("other error is reflected with its own reason", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		err := SyncReady(ctx, log, annotatedObject(nil), &conditions, updates.update, fmt.Errorf("secret is missing"))
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, reasonReconcileError, meta.FindStatusCondition(conditions, ConditionReady).Reason)
+	})
+}
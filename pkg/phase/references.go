@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionReferencesResolved = "ReferencesResolved"
+
+	reasonResolved   = "Resolved"
+	reasonUnresolved = "Unresolved"
+)
+
+// SyncReferencesResolved reflects outcome of resolution of object references in ReferencesResolved
+// condition and returns its error as is. Conditions are persisted with update only if they have changed.
+func SyncReferencesResolved(
+	ctx context.Context,
+	log logr.Logger,
+	object client.Object,
+	conditions *[]metav1.Condition,
+	update UpdateFunc,
+	err error,
+) error {
+	condition := metav1.Condition{
+		Type:               ConditionReferencesResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: object.GetGeneration(),
+		Reason:             reasonResolved,
+		Message:            "references are resolved",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonUnresolved
+		condition.Message = err.Error()
+		if len(condition.Message) > maxConditionMessage {
+			condition.Message = condition.Message[:maxConditionMessage]
+		}
+	}
+
+	if current := meta.FindStatusCondition(*conditions, ConditionReferencesResolved); current != nil &&
+		current.Status == condition.Status &&
+		current.Message == condition.Message {
+		return err
+	}
+	meta.SetStatusCondition(conditions, condition)
+
+	if updateErr := update(ctx, object); updateErr != nil {
+		if err != nil {
+			log.Error(updateErr, "unable to update references resolved condition")
+			return err
+		}
+		return fmt.Errorf("unable to update references resolved condition: %w", updateErr)
+	}
+	log.V(1).Info("references resolved condition updated", "resolved", err == nil)
+	return err
+}
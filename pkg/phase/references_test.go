@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package phase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncReferencesResolved(t *testing.T) {
+	t.Run("unresolved reference is returned and reflected in condition once", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		object := annotatedObject(nil)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+		unresolved := fmt.Errorf("StaticAccessKey default/sakey is not ready")
+
+		// Act
+		err := SyncReferencesResolved(ctx, log, object, &conditions, updates.update, unresolved)
+		require.Error(t, err)
+		err = SyncReferencesResolved(ctx, log, object, &conditions, updates.update, unresolved)
+		require.Error(t, err)
+
+		// Assert
+		condition := meta.FindStatusCondition(conditions, ConditionReferencesResolved)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, reasonUnresolved, condition.Reason)
+		assert.Equal(t, unresolved.Error(), condition.Message)
+		assert.Equal(t, countingUpdate(1), updates)
+	})
+
+	t.Run("resolved reference makes condition true", func(t *testing.T) {
+		// Arrange
+		ctx, log, _ := setup(t)
+		object := annotatedObject(nil)
+		var conditions []metav1.Condition
+		var updates countingUpdate
+
+		// Act
+		require.Error(t, SyncReferencesResolved(
+			ctx, log, object, &conditions, updates.update, fmt.Errorf("not ready"),
+		))
+		require.NoError(t, SyncReferencesResolved(ctx, log, object, &conditions, updates.update, nil))
+
+		// Assert
+		assert.True(t, meta.IsStatusConditionTrue(conditions, ConditionReferencesResolved))
+		assert.Equal(t, countingUpdate(2), updates)
+	})
+}
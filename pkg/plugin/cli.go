@@ -0,0 +1,270 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikey "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	authkey "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/auth"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const usage = `kubectl yc-connectors inspects and repairs objects managed by Yandex Cloud connectors.
+
+Usage:
+  kubectl yc-connectors list [KIND...] [-A]        list objects with their cloud ids and state
+  kubectl yc-connectors describe KIND NAME          show object next to its cloud resource
+  kubectl yc-connectors orphans                     find cloud resources left without objects
+  kubectl yc-connectors reconcile KIND NAME         make connector reconcile object right away
+  kubectl yc-connectors unfinalize KIND NAME        remove finalizer of object stuck in deletion
+  kubectl yc-connectors export --folder ID          write manifests adopting resources of the folder
+  kubectl yc-connectors import-tfstate FILE         write manifests adopting resources of terraform state
+
+Kinds: apikey, authkey, sakey, ycr, ymq, yos, ysa (or their full names).
+Commands that look into the cloud need --cluster-id and either --service-account-key-file
+or --iam-token.
+
+Flags:
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(apikey.AddToScheme(scheme))
+	utilruntime.Must(authkey.AddToScheme(scheme))
+	utilruntime.Must(sakey.AddToScheme(scheme))
+	utilruntime.Must(ycr.AddToScheme(scheme))
+	utilruntime.Must(yos.AddToScheme(scheme))
+	utilruntime.Must(ymq.AddToScheme(scheme))
+	utilruntime.Must(ysa.AddToScheme(scheme))
+}
+
+type options struct {
+	kubeconfig     string
+	kubeContext    string
+	namespace      string
+	clusterID      string
+	keyFile        string
+	iamToken       string
+	ymqEndpoint    string
+	yosEndpoint    string
+	allNamespaces  bool
+	force          bool
+	sakeyName      string
+	folders        util.ArgList
+	serviceAccount util.ArgList
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file.")
+	fs.StringVar(&o.kubeContext, "context", "", "Name of the kubeconfig context to use.")
+	fs.StringVar(&o.namespace, "namespace", "", "Namespace of the objects, defaults to the one of the context.")
+	fs.StringVar(&o.namespace, "n", "", "Shorthand for --namespace.")
+	fs.StringVar(&o.clusterID, "cluster-id", os.Getenv("YC_CONNECTORS_CLUSTER_ID"),
+		"ID of the cluster connectors manager was started with, defaults to $YC_CONNECTORS_CLUSTER_ID.")
+	fs.StringVar(&o.keyFile, "service-account-key-file", "",
+		"Path to service account key file used for authorization in Yandex Cloud.")
+	fs.StringVar(&o.iamToken, "iam-token", os.Getenv("YC_IAM_TOKEN"),
+		"IAM token used for authorization in Yandex Cloud, defaults to $YC_IAM_TOKEN.")
+	fs.StringVar(&o.ymqEndpoint, "ymq-endpoint", ymqconfig.DefaultEndpoint, "Endpoint of Yandex Message Queue.")
+	fs.StringVar(&o.yosEndpoint, "yos-endpoint", yosconfig.DefaultEndpoint, "Endpoint of Yandex Object Storage.")
+	fs.BoolVar(&o.allNamespaces, "all-namespaces", false, "List objects in all namespaces.")
+	fs.BoolVar(&o.allNamespaces, "A", false, "Shorthand for --all-namespaces.")
+	fs.BoolVar(&o.force, "force", false, "Remove finalizer even if cloud resource still exists or cannot be checked.")
+	fs.Var(&o.folders, "folder", "Folder to export, or additional folders to look for orphaned registries in.")
+	fs.Var(&o.serviceAccount, "service-account", "Additional service accounts to look for orphaned keys of.")
+	fs.StringVar(&o.sakeyName, "sakey", "",
+		"Name of StaticAccessKey used to look up exported buckets and queues, they are not exported if not set. "+
+			"For import-tfstate, the one used for buckets and queues whose access key is not in the state. "+
+			"For orphans, additional key to look for orphaned buckets and queues with.")
+}
+
+// Execute runs command given by args, which do not include the name of the binary.
+func Execute(ctx context.Context, args []string, out io.Writer) error {
+	var opts options
+	fs := flag.NewFlagSet("kubectl yc-connectors", flag.ContinueOnError)
+	fs.SetOutput(out)
+	opts.register(fs)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(out, usage)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return fmt.Errorf("command is required")
+	}
+	command, positional := positional[0], positional[1:]
+
+	switch command {
+	case "list", "orphans":
+	case "export":
+		if len(opts.folders) != 1 {
+			return fmt.Errorf("export expects exactly one --folder")
+		}
+	case "describe", "reconcile", "unfinalize":
+		if len(positional) != 2 {
+			return fmt.Errorf("%s expects KIND and NAME", command)
+		}
+	case "import-tfstate":
+		if len(positional) != 1 {
+			return fmt.Errorf("import-tfstate expects FILE")
+		}
+		return importTerraformState(&opts, positional[0], out)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	p, err := newPlugin(&opts, out)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "list":
+		return p.List(ctx, positional, opts.allNamespaces)
+	case "orphans":
+		return p.Orphans(ctx, opts.folders, opts.serviceAccount, opts.sakeyName)
+	case "export":
+		return p.Export(ctx, opts.folders[0], opts.sakeyName)
+	case "describe":
+		return p.Describe(ctx, positional[0], positional[1])
+	case "reconcile":
+		return p.Reconcile(ctx, positional[0], positional[1])
+	default:
+		return p.Unfinalize(ctx, positional[0], positional[1], opts.force)
+	}
+}
+
+// parseInterspersed lets flags follow positional arguments, as kubectl users are used to.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// importTerraformState does not need the cluster, only the namespace objects are written to.
+func importTerraformState(opts *options, path string, out io.Writer) error {
+	namespace, err := resolveNamespace(opts, newClientConfig(opts))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open terraform state: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	p := &Plugin{Out: out, Namespace: namespace}
+	return p.ImportTerraformState(f, opts.sakeyName)
+}
+
+func newClientConfig(opts *options) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = opts.kubeconfig
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		rules, &clientcmd.ConfigOverrides{CurrentContext: opts.kubeContext},
+	)
+}
+
+func resolveNamespace(opts *options, clientConfig clientcmd.ClientConfig) (string, error) {
+	if opts.namespace != "" {
+		return opts.namespace, nil
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return "", fmt.Errorf("unable to get namespace of the context: %w", err)
+	}
+	return namespace, nil
+}
+
+func newPlugin(opts *options, out io.Writer) (*Plugin, error) {
+	clientConfig := newClientConfig(opts)
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get kubernetes config: %w", err)
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kubernetes client: %w", err)
+	}
+
+	namespace, err := resolveNamespace(opts, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plugin{
+		Client:    cl,
+		Out:       out,
+		Namespace: namespace,
+		ClusterID: opts.clusterID,
+		NewCloud: func(ctx context.Context) (*Cloud, error) {
+			creds, err := newCredentials(opts)
+			if err != nil {
+				return nil, err
+			}
+			sdk, err := ycsdk.Build(ctx, ycsdk.Config{Credentials: creds})
+			if err != nil {
+				return nil, fmt.Errorf("unable to build sdk: %w", err)
+			}
+			return NewCloud(sdk, opts.ymqEndpoint, opts.yosEndpoint)
+		},
+		Now: time.Now,
+	}, nil
+}
+
+func newCredentials(opts *options) (ycsdk.Credentials, error) {
+	switch {
+	case opts.keyFile != "":
+		return auth.NewKeyFileCredentials(logr.Discard(), opts.keyFile, 0)
+	case opts.iamToken != "":
+		return ycsdk.NewIAMTokenCredentials(opts.iamToken), nil
+	default:
+		return nil, fmt.Errorf("either --service-account-key-file or --iam-token must be set")
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	apikeycontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller"
+	authkeycontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/controller"
+	sakeycontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller"
+	ycrcontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller"
+	ymqcontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller"
+	yoscontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller"
+	ysacontroller "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/gc"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+const (
+	StateTerminating = "Terminating"
+	StatePaused      = "Paused"
+	StateFailed      = "Failed"
+	StateReady       = "Ready"
+	StatePending     = "Pending"
+)
+
+func state(k *kind, obj client.Object) string {
+	switch {
+	case obj.GetDeletionTimestamp() != nil:
+		return StateTerminating
+	case meta.IsStatusConditionTrue(k.conditions(obj), phase.ConditionPaused):
+		return StatePaused
+	case meta.IsStatusConditionFalse(k.conditions(obj), phase.ConditionReady):
+		return StateFailed
+	case k.cloudID(obj) != "":
+		return StateReady
+	default:
+		return StatePending
+	}
+}
+
+// List prints objects of the given kinds (all kinds if none are given) with their cloud ids and state.
+func (p *Plugin) List(ctx context.Context, kindNames []string, allNamespaces bool) error {
+	selected := make([]*kind, 0, len(kinds))
+	if len(kindNames) == 0 {
+		for i := range kinds {
+			selected = append(selected, &kinds[i])
+		}
+	}
+	for _, name := range kindNames {
+		k, err := kindByName(name)
+		if err != nil {
+			return err
+		}
+		selected = append(selected, k)
+	}
+
+	var opts []client.ListOption
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(p.Namespace))
+	}
+
+	w := tabwriter.NewWriter(p.Out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tCLOUD ID\tSTATE")
+	for _, k := range selected {
+		lst := k.newList()
+		if err := p.Client.List(ctx, lst, opts...); err != nil {
+			return fmt.Errorf("unable to list %s objects: %w", k.shortName, err)
+		}
+		items, err := meta.ExtractList(lst)
+		if err != nil {
+			return fmt.Errorf("unable to extract %s objects: %w", k.shortName, err)
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			cloudID := k.cloudID(obj)
+			if cloudID == "" {
+				cloudID = "<none>"
+			}
+			_, _ = fmt.Fprintf(
+				w, "%s\t%s\t%s\t%s\t%s\n", k.shortName, obj.GetNamespace(), obj.GetName(), cloudID, state(k, obj),
+			)
+		}
+	}
+	return w.Flush()
+}
+
+// Describe prints spec and status of the object next to the state of its cloud resource.
+func (p *Plugin) Describe(ctx context.Context, kindName, name string) error {
+	k, obj, err := p.get(ctx, kindName, name)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(p.Out, "Kind:        %s\n", k.longName)
+	_, _ = fmt.Fprintf(p.Out, "Name:        %s/%s\n", obj.GetNamespace(), obj.GetName())
+	_, _ = fmt.Fprintf(p.Out, "State:       %s\n", state(k, obj))
+	_, _ = fmt.Fprintf(p.Out, "Finalizers:  %s\n", strings.Join(obj.GetFinalizers(), ", "))
+	if err := p.printSection("Spec", k.spec(obj)); err != nil {
+		return err
+	}
+	if err := p.printSection("Status", k.status(obj)); err != nil {
+		return err
+	}
+
+	cloud, err := p.cloud(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := k.lookup(ctx, p, cloud, obj)
+	if err != nil {
+		return fmt.Errorf("unable to look up cloud resource: %w", err)
+	}
+	if res == nil {
+		_, _ = fmt.Fprintln(p.Out, "Cloud:       <not found>")
+		return nil
+	}
+	return p.printSection("Cloud", res)
+}
+
+func (p *Plugin) printSection(title string, value interface{}) error {
+	var data []byte
+	var err error
+	if message, ok := value.(proto.Message); ok {
+		var js []byte
+		js, err = protojson.Marshal(message)
+		if err == nil {
+			data, err = yaml.JSONToYAML(js)
+		}
+	} else {
+		data, err = yaml.Marshal(value)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to format %s: %w", strings.ToLower(title), err)
+	}
+
+	_, _ = fmt.Fprintf(p.Out, "%s:\n", title)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		_, _ = fmt.Fprintf(p.Out, "  %s\n", line)
+	}
+	return nil
+}
+
+// Orphans prints cloud resources that carry id of this cluster but have no object backing them.
+// Folders, service accounts and static access keys of existing objects are always looked through.
+func (p *Plugin) Orphans(ctx context.Context, folders, serviceAccounts []string, sakeyName string) error {
+	if err := p.requireClusterID(); err != nil {
+		return err
+	}
+	cloud, err := p.cloud(ctx)
+	if err != nil {
+		return err
+	}
+
+	var keys []types.NamespacedName
+	if sakeyName != "" {
+		keys = append(keys, types.NamespacedName{Namespace: p.Namespace, Name: sakeyName})
+	}
+	clients := awsutils.NewClientCache()
+
+	collectors := []gc.Collector{
+		apikeycontroller.NewOrphanCollector(p.Client, cloud.SDK, p.ClusterID, serviceAccounts),
+		authkeycontroller.NewOrphanCollector(p.Client, cloud.SDK, p.ClusterID, serviceAccounts),
+		sakeycontroller.NewOrphanCollector(p.Client, cloud.SDK, p.ClusterID, serviceAccounts),
+		ycrcontroller.NewOrphanCollector(p.Client, cloud.SDK, p.ClusterID, folders),
+		ymqcontroller.NewOrphanCollector(p.Client, cloud.YMQEndpoint, clients, p.ClusterID, keys),
+		yoscontroller.NewOrphanCollector(p.Client, cloud.YOSEndpoint, clients, p.ClusterID, keys),
+		ysacontroller.NewOrphanCollector(p.Client, cloud.SDK, p.ClusterID, folders),
+	}
+
+	w := tabwriter.NewWriter(p.Out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tLOCATION\tNAME\tCLOUD ID")
+	for _, collector := range collectors {
+		orphans, err := collector.Orphans(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to find orphaned %s resources: %w", collector.Kind(), err)
+		}
+		for _, orphan := range orphans {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", collector.Kind(), orphan.Location, orphan.Name, orphan.ID)
+		}
+	}
+	return w.Flush()
+}
+
+// Reconcile bumps annotation of the object, so that connector reconciles it right away.
+func (p *Plugin) Reconcile(ctx context.Context, kindName, name string) error {
+	k, obj, err := p.get(ctx, kindName, name)
+	if err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[config.ReconcileRequestedAnnotation] = p.Now().UTC().Format(time.RFC3339Nano)
+	obj.SetAnnotations(annotations)
+	if err := p.Client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("unable to update object: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(p.Out, "%s %s/%s reconcile requested\n", k.shortName, obj.GetNamespace(), obj.GetName())
+	if state(k, obj) == StatePaused {
+		_, _ = fmt.Fprintf(
+			p.Out, "warning: object is paused and will not be reconciled until %s annotation is removed\n",
+			config.PausedAnnotation,
+		)
+	}
+	return nil
+}
+
+// Unfinalize removes finalizer of the connector from the object that is stuck in deletion.
+// Unless forced, it refuses to do so while cloud resource of the object still exists,
+// because it would be left behind without any object managing it.
+func (p *Plugin) Unfinalize(ctx context.Context, kindName, name string, force bool) error {
+	k, obj, err := p.get(ctx, kindName, name)
+	if err != nil {
+		return err
+	}
+
+	if !util.ContainsString(obj.GetFinalizers(), k.finalizer) {
+		_, _ = fmt.Fprintf(p.Out, "%s %s/%s has no finalizer %s\n", k.shortName, obj.GetNamespace(), name, k.finalizer)
+		return nil
+	}
+	if obj.GetDeletionTimestamp() == nil {
+		return fmt.Errorf("object is not being deleted, connector would put the finalizer back")
+	}
+
+	if !force {
+		cloud, err := p.cloud(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := k.lookup(ctx, p, cloud, obj)
+		if err != nil {
+			return fmt.Errorf("unable to check cloud resource, use --force to skip the check: %w", err)
+		}
+		if res != nil {
+			return fmt.Errorf(
+				"cloud resource of the object still exists and would be orphaned, " +
+					"delete it or use --force to leave it behind",
+			)
+		}
+	}
+
+	obj.SetFinalizers(util.RemoveString(obj.GetFinalizers(), k.finalizer))
+	if err := p.Client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("unable to update object: %w", err)
+	}
+	_, _ = fmt.Fprintf(p.Out, "%s %s/%s finalizer removed\n", k.shortName, obj.GetNamespace(), name)
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	yosutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+)
+
+// ServiceAccountLister lists service accounts of a folder, access keys of which are exported.
+type ServiceAccountLister interface {
+	List(ctx context.Context, folderID string) ([]*iam.ServiceAccount, error)
+}
+
+type serviceAccountListerSDK struct {
+	sdk *ycsdk.SDK
+}
+
+func (r serviceAccountListerSDK) List(ctx context.Context, folderID string) ([]*iam.ServiceAccount, error) {
+	var res []*iam.ServiceAccount
+	request := &iam.ListServiceAccountsRequest{FolderId: folderID}
+	for {
+		resp, err := r.sdk.IAM().ServiceAccount().List(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, resp.ServiceAccounts...)
+		if resp.NextPageToken == "" {
+			return res, nil
+		}
+		request.PageToken = resp.NextPageToken
+	}
+}
+
+// Export writes manifests of resources of the folder that are not managed by connectors yet,
+// each marked to be adopted by connector instead of being created anew. Buckets and queues are
+// exported only if sakeyName is given, they are looked up with the key and will be managed with it.
+func (p *Plugin) Export(ctx context.Context, folderID, sakeyName string) error {
+	cloud, err := p.cloud(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := &manifestWriter{out: p.Out}
+	w.comment("Exported from folder %s, review before applying.", folderID)
+	if err := p.exportRegistries(ctx, w, cloud, folderID); err != nil {
+		return err
+	}
+	if err := p.exportAccessKeys(ctx, w, cloud, folderID); err != nil {
+		return err
+	}
+
+	if sakeyName == "" {
+		w.comment("Buckets and queues are not exported, set --sakey to look them up.")
+		w.close()
+		return nil
+	}
+	cred, err := awsutils.CredentialsFromStaticAccessKey(ctx, p.Namespace, sakeyName, p.Client)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+	if err := p.exportBuckets(ctx, w, cloud, cred, sakeyName); err != nil {
+		return err
+	}
+	if err := p.exportQueues(ctx, w, cloud, cred, sakeyName); err != nil {
+		return err
+	}
+	w.close()
+	return nil
+}
+
+func (p *Plugin) objectMeta(name, cloudID string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   p.Namespace,
+		Annotations: map[string]string{config.AdoptAnnotation: cloudID},
+	}
+}
+
+func (p *Plugin) exportRegistries(ctx context.Context, w *manifestWriter, cloud *Cloud, folderID string) error {
+	lst, err := cloud.YCR.List(ctx, folderID)
+	if err != nil {
+		return fmt.Errorf("unable to list registries: %w", err)
+	}
+	for _, registry := range lst {
+		if cluster, ok := registry.Labels[config.CloudClusterLabel]; ok {
+			w.comment("Registry %s is skipped, it is already managed from cluster %s.", registry.Id, cluster)
+			continue
+		}
+		if err := w.object(&ycr.YandexContainerRegistry{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ycr.GroupVersion.String(), Kind: ycrconfig.LongName},
+			ObjectMeta: p.objectMeta(objectName(registry.Name), registry.Id),
+			Spec:       ycr.YandexContainerRegistrySpec{Name: registry.Name, FolderID: registry.FolderId},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) exportAccessKeys(ctx context.Context, w *manifestWriter, cloud *Cloud, folderID string) error {
+	accounts, err := cloud.ServiceAccounts.List(ctx, folderID)
+	if err != nil {
+		return fmt.Errorf("unable to list service accounts: %w", err)
+	}
+	for _, account := range accounts {
+		keys, err := cloud.SAKey.List(ctx, account.Id)
+		if err != nil {
+			return fmt.Errorf("unable to list access keys of service account %s: %w", account.Id, err)
+		}
+		for _, key := range keys {
+			if cluster, _, ok := sakeyconfig.ParseStaticAccessKeyDescription(key.Description); ok {
+				w.comment("Access key %s is skipped, it is already managed from cluster %s.", key.Id, cluster)
+				continue
+			}
+
+			name := objectName(account.Name, key.Id)
+			if err := w.object(&sakey.StaticAccessKey{
+				TypeMeta:   metav1.TypeMeta{APIVersion: sakey.GroupVersion.String(), Kind: sakeyconfig.LongName},
+				ObjectMeta: p.objectMeta(name, key.Id),
+				Spec:       sakey.StaticAccessKeySpec{ServiceAccountID: account.Id},
+			}); err != nil {
+				return err
+			}
+
+			w.comment("Cloud returns secret part of access key %s only on creation, fill it in.", key.Id)
+			if err := w.object(&v1.Secret{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secret.Name(name, sakeyconfig.ShortName),
+					Namespace: p.Namespace,
+					Labels:    map[string]string{"kind": sakeyconfig.ShortName},
+				},
+				StringData: map[string]string{"key": key.KeyId, "secret": ""},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) exportBuckets(
+	ctx context.Context, w *manifestWriter, cloud *Cloud, cred *credentials.Credentials, sakeyName string,
+) error {
+	sdk, err := yosutils.NewS3Client(ctx, cloud.YOSEndpoint, cred)
+	if err != nil {
+		return fmt.Errorf("unable to build sdk: %w", err)
+	}
+	lst, err := cloud.YOS.List(ctx, sdk)
+	if err != nil {
+		return fmt.Errorf("unable to list buckets: %w", err)
+	}
+	for _, bucket := range lst {
+		if err := w.object(&yos.YandexObjectStorage{
+			TypeMeta:   metav1.TypeMeta{APIVersion: yos.GroupVersion.String(), Kind: yosconfig.LongName},
+			ObjectMeta: p.objectMeta(objectName(*bucket.Name), *bucket.Name),
+			Spec:       yos.YandexObjectStorageSpec{Name: *bucket.Name, SAKeyName: sakeyName},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) exportQueues(
+	ctx context.Context, w *manifestWriter, cloud *Cloud, cred *credentials.Credentials, sakeyName string,
+) error {
+	sdk, err := ymqutils.NewSQSClient(ctx, cloud.YMQEndpoint, cred)
+	if err != nil {
+		return fmt.Errorf("unable to build sdk: %w", err)
+	}
+	lst, err := cloud.YMQ.List(ctx, sdk)
+	if err != nil {
+		return fmt.Errorf("unable to list queues: %w", err)
+	}
+	for _, queueURL := range lst {
+		parsed, err := url.Parse(*queueURL)
+		if err != nil {
+			return fmt.Errorf("unable to parse queue url %s: %w", *queueURL, err)
+		}
+		name := path.Base(parsed.Path)
+
+		attributes, err := cloud.YMQ.GetAttributes(ctx, sdk, *queueURL)
+		if err != nil {
+			return fmt.Errorf("unable to get attributes of queue %s: %w", name, err)
+		}
+		spec, unsupported, err := ymqutils.SpecFromAttributes(name, attributes)
+		if err != nil {
+			return fmt.Errorf("unable to convert attributes of queue %s: %w", name, err)
+		}
+		for _, attribute := range unsupported {
+			w.comment("Attribute %s of queue %s cannot be represented and is dropped.", attribute, name)
+		}
+		spec.SAKeyName = sakeyName
+
+		if err := w.object(&ymq.YandexMessageQueue{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ymq.GroupVersion.String(), Kind: ymqconfig.LongName},
+			ObjectMeta: p.objectMeta(objectName(name), *queueURL),
+			Spec:       *spec,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
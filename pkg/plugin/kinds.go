@@ -0,0 +1,329 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikey "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/api/v1"
+	apikeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/config"
+	apikeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/pkg/util"
+	authkey "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/api/v1"
+	authkeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/config"
+	authkeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/pkg/util"
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	sakeyutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/util"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ycrutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/util"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	ymqutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/util"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	yosutils "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/util"
+	ysa "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/api/v1"
+	ysaconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/config"
+	ysautils "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/pkg/util"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/awsutils"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// kind describes how plugin works with objects of one connector.
+type kind struct {
+	shortName string
+	longName  string
+	finalizer string
+
+	newObject func() client.Object
+	newList   func() client.ObjectList
+	// cloudID returns id of the cloud resource recorded in the object, empty if it is not known yet.
+	cloudID    func(obj client.Object) string
+	spec       func(obj client.Object) interface{}
+	status     func(obj client.Object) interface{}
+	conditions func(obj client.Object) []metav1.Condition
+	// lookup finds cloud resource of the object the same way connector does,
+	// it returns nil without error if resource does not exist.
+	lookup func(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error)
+}
+
+var kinds = []kind{
+	{
+		shortName: apikeyconfig.ShortName,
+		longName:  apikeyconfig.LongName,
+		finalizer: apikeyconfig.FinalizerName,
+		newObject: func() client.Object { return &apikey.IAMApiKey{} },
+		newList:   func() client.ObjectList { return &apikey.IAMApiKeyList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*apikey.IAMApiKey).Status.KeyID },
+		spec:      func(obj client.Object) interface{} { return obj.(*apikey.IAMApiKey).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*apikey.IAMApiKey).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*apikey.IAMApiKey).Status.Conditions
+		},
+		lookup: lookupAPIKey,
+	},
+	{
+		shortName: authkeyconfig.ShortName,
+		longName:  authkeyconfig.LongName,
+		finalizer: authkeyconfig.FinalizerName,
+		newObject: func() client.Object { return &authkey.IAMAuthorizedKey{} },
+		newList:   func() client.ObjectList { return &authkey.IAMAuthorizedKeyList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*authkey.IAMAuthorizedKey).Status.KeyID },
+		spec:      func(obj client.Object) interface{} { return obj.(*authkey.IAMAuthorizedKey).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*authkey.IAMAuthorizedKey).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*authkey.IAMAuthorizedKey).Status.Conditions
+		},
+		lookup: lookupAuthorizedKey,
+	},
+	{
+		shortName: sakeyconfig.ShortName,
+		longName:  sakeyconfig.LongName,
+		finalizer: sakeyconfig.FinalizerName,
+		newObject: func() client.Object { return &sakey.StaticAccessKey{} },
+		newList:   func() client.ObjectList { return &sakey.StaticAccessKeyList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*sakey.StaticAccessKey).Status.KeyID },
+		spec:      func(obj client.Object) interface{} { return obj.(*sakey.StaticAccessKey).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*sakey.StaticAccessKey).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*sakey.StaticAccessKey).Status.Conditions
+		},
+		lookup: lookupStaticAccessKey,
+	},
+	{
+		shortName: ycrconfig.ShortName,
+		longName:  ycrconfig.LongName,
+		finalizer: ycrconfig.FinalizerName,
+		newObject: func() client.Object { return &ycr.YandexContainerRegistry{} },
+		newList:   func() client.ObjectList { return &ycr.YandexContainerRegistryList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*ycr.YandexContainerRegistry).Status.ID },
+		spec:      func(obj client.Object) interface{} { return obj.(*ycr.YandexContainerRegistry).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*ycr.YandexContainerRegistry).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*ycr.YandexContainerRegistry).Status.Conditions
+		},
+		lookup: lookupRegistry,
+	},
+	{
+		shortName: ymqconfig.ShortName,
+		longName:  ymqconfig.LongName,
+		finalizer: ymqconfig.FinalizerName,
+		newObject: func() client.Object { return &ymq.YandexMessageQueue{} },
+		newList:   func() client.ObjectList { return &ymq.YandexMessageQueueList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*ymq.YandexMessageQueue).Status.QueueURL },
+		spec:      func(obj client.Object) interface{} { return obj.(*ymq.YandexMessageQueue).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*ymq.YandexMessageQueue).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*ymq.YandexMessageQueue).Status.Conditions
+		},
+		lookup: lookupQueue,
+	},
+	{
+		shortName: yosconfig.ShortName,
+		longName:  yosconfig.LongName,
+		finalizer: yosconfig.FinalizerName,
+		newObject: func() client.Object { return &yos.YandexObjectStorage{} },
+		newList:   func() client.ObjectList { return &yos.YandexObjectStorageList{} },
+		// Bucket is identified by its name, it is known only after bucket is created
+		cloudID: func(obj client.Object) string {
+			if !util.ContainsString(obj.GetFinalizers(), yosconfig.FinalizerName) {
+				return ""
+			}
+			return obj.(*yos.YandexObjectStorage).Spec.Name
+		},
+		spec:   func(obj client.Object) interface{} { return obj.(*yos.YandexObjectStorage).Spec },
+		status: func(obj client.Object) interface{} { return obj.(*yos.YandexObjectStorage).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*yos.YandexObjectStorage).Status.Conditions
+		},
+		lookup: lookupBucket,
+	},
+	{
+		shortName: ysaconfig.ShortName,
+		longName:  ysaconfig.LongName,
+		finalizer: ysaconfig.FinalizerName,
+		newObject: func() client.Object { return &ysa.YandexServiceAccount{} },
+		newList:   func() client.ObjectList { return &ysa.YandexServiceAccountList{} },
+		cloudID:   func(obj client.Object) string { return obj.(*ysa.YandexServiceAccount).Status.ID },
+		spec:      func(obj client.Object) interface{} { return obj.(*ysa.YandexServiceAccount).Spec },
+		status:    func(obj client.Object) interface{} { return obj.(*ysa.YandexServiceAccount).Status },
+		conditions: func(obj client.Object) []metav1.Condition {
+			return obj.(*ysa.YandexServiceAccount).Status.Conditions
+		},
+		lookup: lookupServiceAccount,
+	},
+}
+
+// kindByName accepts both short and long (case-insensitive) names of the kind.
+func kindByName(name string) (*kind, error) {
+	for i := range kinds {
+		if name == kinds[i].shortName || strings.EqualFold(name, kinds[i].longName) {
+			return &kinds[i], nil
+		}
+	}
+	names := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		names = append(names, k.shortName)
+	}
+	return nil, fmt.Errorf("unknown kind %q, must be one of: %s", name, strings.Join(names, ", "))
+}
+
+func lookupAPIKey(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	if err := p.requireClusterID(); err != nil {
+		return nil, err
+	}
+	object := obj.(*apikey.IAMApiKey)
+	res, err := apikeyutils.GetAPIKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, p.ClusterID, object.Name, cloud.APIKey,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, apikeyconfig.ErrCodeAPIKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func lookupAuthorizedKey(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	if err := p.requireClusterID(); err != nil {
+		return nil, err
+	}
+	object := obj.(*authkey.IAMAuthorizedKey)
+	res, err := authkeyutils.GetAuthorizedKey(
+		ctx, object.Status.KeyID, object.Spec.ServiceAccountID, p.ClusterID, object.Name, cloud.AuthKey,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, authkeyconfig.ErrCodeAuthorizedKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func lookupStaticAccessKey(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	if err := p.requireClusterID(); err != nil {
+		return nil, err
+	}
+	object := obj.(*sakey.StaticAccessKey)
+	res, err := sakeyutils.GetStaticAccessKey(
+		ctx, object.Status.KeyID, sakeyutils.ServiceAccountID(object), p.ClusterID, object.Name, cloud.SAKey,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, sakeyconfig.ErrCodeSAKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func lookupRegistry(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	if err := p.requireClusterID(); err != nil {
+		return nil, err
+	}
+	object := obj.(*ycr.YandexContainerRegistry)
+	res, err := ycrutils.GetRegistry(
+		ctx, object.Status.ID, object.Spec.FolderID, object.Name, p.ClusterID, cloud.YCR,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, ycrconfig.ErrCodeYCRNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func lookupServiceAccount(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	if err := p.requireClusterID(); err != nil {
+		return nil, err
+	}
+	object := obj.(*ysa.YandexServiceAccount)
+	res, err := ysautils.GetServiceAccount(
+		ctx, object.Status.ID, object.Spec.FolderID, object.Name, p.ClusterID, cloud.YSA,
+	)
+	if err != nil {
+		if errorhandling.CheckConnectorErrorCode(err, ysaconfig.ErrCodeYSANotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// queueState is what is known about queue in the cloud.
+type queueState struct {
+	URL        string             `json:"url"`
+	Attributes map[string]*string `json:"attributes"`
+}
+
+func lookupQueue(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	object := obj.(*ymq.YandexMessageQueue)
+	key := awsutils.StaticAccessKeyName(
+		object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+	)
+	cred, err := awsutils.GrantedCredentials(ctx, ymqconfig.LongName, object.Namespace, key, p.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+	sdk, err := ymqutils.NewSQSClient(ctx, cloud.YMQEndpoint, cred)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build sdk: %w", err)
+	}
+
+	url := object.Status.QueueURL
+	if url == "" {
+		url, err = cloud.YMQ.GetURL(ctx, sdk, object.Spec.Name)
+		if err != nil {
+			if awsutils.CheckSQSDoesNotExist(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unable to get queue url: %w", err)
+		}
+	}
+
+	attributes, err := cloud.YMQ.GetAttributes(ctx, sdk, url)
+	if err != nil {
+		if awsutils.CheckSQSDoesNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get queue attributes: %w", err)
+	}
+	return &queueState{URL: url, Attributes: attributes}, nil
+}
+
+func lookupBucket(ctx context.Context, p *Plugin, cloud *Cloud, obj client.Object) (interface{}, error) {
+	object := obj.(*yos.YandexObjectStorage)
+	key := awsutils.StaticAccessKeyName(
+		object.Namespace, object.Spec.SAKeyNamespace, object.Spec.SAKeyName, object.Spec.SAKeyRef,
+	)
+	cred, err := awsutils.GrantedCredentials(ctx, yosconfig.LongName, object.Namespace, key, p.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+	sdk, err := yosutils.NewS3Client(ctx, cloud.YOSEndpoint, cred)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build sdk: %w", err)
+	}
+
+	lst, err := cloud.YOS.List(ctx, sdk)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list buckets: %w", err)
+	}
+	for _, bucket := range lst {
+		if bucket.Name != nil && *bucket.Name == object.Spec.Name {
+			return bucket, nil
+		}
+	}
+	return nil, nil
+}
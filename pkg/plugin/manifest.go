@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestWriter writes objects as multi-document YAML, comments are attached to the next object.
+type manifestWriter struct {
+	out      io.Writer
+	comments []string
+	objects  int
+}
+
+func (w *manifestWriter) comment(format string, args ...interface{}) {
+	w.comments = append(w.comments, fmt.Sprintf(format, args...))
+}
+
+func (w *manifestWriter) object(obj runtime.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("unable to convert object: %w", err)
+	}
+	// Neither status nor server-populated metadata belong to the manifest
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("unable to marshal object: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(w.out, "---")
+	w.flushComments()
+	_, err = w.out.Write(data)
+	w.objects++
+	return err
+}
+
+// close writes comments that are not attached to any object.
+func (w *manifestWriter) close() {
+	if len(w.comments) > 0 {
+		_, _ = fmt.Fprintln(w.out, "---")
+		w.flushComments()
+	}
+}
+
+func (w *manifestWriter) flushComments() {
+	for _, c := range w.comments {
+		_, _ = fmt.Fprintf(w.out, "# %s\n", c)
+	}
+	w.comments = nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// objectName turns name of cloud resource into a valid name of kubernetes object.
+func objectName(parts ...string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(name, "-")
+}
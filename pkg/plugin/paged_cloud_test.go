@@ -0,0 +1,119 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/grpc"
+
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+)
+
+// page returns index of the item that page with the token consists of, and token of the next page.
+// Every page of the paged cloud holds a single item.
+func page(token string, items int) (int, string) {
+	i, _ := strconv.Atoi(token)
+	if i+1 < items {
+		return i, strconv.Itoa(i + 1)
+	}
+	return i, ""
+}
+
+type pagedEndpoints struct {
+	endpoint.UnimplementedApiEndpointServiceServer
+	address string
+}
+
+func (r *pagedEndpoints) List(
+	context.Context, *endpoint.ListApiEndpointsRequest,
+) (*endpoint.ListApiEndpointsResponse, error) {
+	return &endpoint.ListApiEndpointsResponse{Endpoints: []*endpoint.ApiEndpoint{
+		{Id: string(ycsdk.ContainerRegistryServiceID), Address: r.address},
+		{Id: string(ycsdk.IAMServiceID), Address: r.address},
+	}}, nil
+}
+
+type pagedRegistries struct {
+	containerregistry.UnimplementedRegistryServiceServer
+	registries []*containerregistry.Registry
+}
+
+func (r *pagedRegistries) List(
+	_ context.Context, request *containerregistry.ListRegistriesRequest,
+) (*containerregistry.ListRegistriesResponse, error) {
+	i, next := page(request.PageToken, len(r.registries))
+	return &containerregistry.ListRegistriesResponse{
+		Registries:    r.registries[i : i+1],
+		NextPageToken: next,
+	}, nil
+}
+
+type pagedServiceAccounts struct {
+	iam.UnimplementedServiceAccountServiceServer
+	accounts []*iam.ServiceAccount
+}
+
+func (r *pagedServiceAccounts) List(
+	_ context.Context, request *iam.ListServiceAccountsRequest,
+) (*iam.ListServiceAccountsResponse, error) {
+	i, next := page(request.PageToken, len(r.accounts))
+	return &iam.ListServiceAccountsResponse{ServiceAccounts: r.accounts[i : i+1], NextPageToken: next}, nil
+}
+
+type pagedAccessKeys struct {
+	awscompatibility.UnimplementedAccessKeyServiceServer
+	keys map[string][]*awscompatibility.AccessKey
+}
+
+func (r *pagedAccessKeys) List(
+	_ context.Context, request *awscompatibility.ListAccessKeysRequest,
+) (*awscompatibility.ListAccessKeysResponse, error) {
+	keys := r.keys[request.ServiceAccountId]
+	i, next := page(request.PageToken, len(keys))
+	return &awscompatibility.ListAccessKeysResponse{AccessKeys: keys[i : i+1], NextPageToken: next}, nil
+}
+
+// setupPagedCloud makes plugin talk to cloud that returns registries, service accounts and their
+// access keys one per page.
+func setupPagedCloud(
+	t *testing.T,
+	p *Plugin,
+	registries []*containerregistry.Registry,
+	accounts []*iam.ServiceAccount,
+	keys map[string][]*awscompatibility.AccessKey,
+) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	endpoint.RegisterApiEndpointServiceServer(server, &pagedEndpoints{address: lis.Addr().String()})
+	containerregistry.RegisterRegistryServiceServer(server, &pagedRegistries{registries: registries})
+	iam.RegisterServiceAccountServiceServer(server, &pagedServiceAccounts{accounts: accounts})
+	awscompatibility.RegisterAccessKeyServiceServer(server, &pagedAccessKeys{keys: keys})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	sdk, err := ycsdk.Build(context.Background(), ycsdk.Config{
+		Credentials: ycsdk.NewIAMTokenCredentials("token"),
+		Endpoint:    lis.Addr().String(),
+		Plaintext:   true,
+	})
+	require.NoError(t, err)
+	cloud, err := NewCloud(sdk, ymqconfig.DefaultEndpoint, yosconfig.DefaultEndpoint)
+	require.NoError(t, err)
+	p.NewCloud = func(_ context.Context) (*Cloud, error) {
+		return cloud, nil
+	}
+}
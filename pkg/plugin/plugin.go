@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikeyadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/apikey/controller/adapter"
+	authkeyadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/authkey/controller/adapter"
+	sakeyadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller/adapter"
+	ycradapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller/adapter"
+	ymqadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller/adapter"
+	yosadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller/adapter"
+	ysaadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/ysa/controller/adapter"
+)
+
+// Plugin implements commands of kubectl yc-connectors.
+type Plugin struct {
+	Client client.Client
+	Out    io.Writer
+	// Namespace is used by commands working with single object and by list unless all namespaces are requested.
+	Namespace string
+	// ClusterID is the one connectors manager was started with, cloud resources are matched by it.
+	ClusterID string
+	// NewCloud is called only by commands that need access to the cloud.
+	NewCloud func(ctx context.Context) (*Cloud, error)
+	Now      func() time.Time
+}
+
+// Cloud holds everything plugin needs to look up cloud resources.
+type Cloud struct {
+	SDK             *ycsdk.SDK
+	APIKey          apikeyadapter.IAMApiKeyAdapter
+	AuthKey         authkeyadapter.IAMAuthorizedKeyAdapter
+	SAKey           sakeyadapter.StaticAccessKeyAdapter
+	YCR             ycradapter.YandexContainerRegistryAdapter
+	YMQ             ymqadapter.YandexMessageQueueAdapter
+	YOS             yosadapter.YandexObjectStorageAdapter
+	YSA             ysaadapter.YandexServiceAccountAdapter
+	ServiceAccounts ServiceAccountLister
+	YMQEndpoint     string
+	YOSEndpoint     string
+}
+
+func NewCloud(sdk *ycsdk.SDK, ymqEndpoint, yosEndpoint string) (*Cloud, error) {
+	yos, err := yosadapter.NewYandexObjectStorageAdapterSDK()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create object storage adapter: %w", err)
+	}
+	return &Cloud{
+		SDK:             sdk,
+		APIKey:          apikeyadapter.NewIAMApiKeyAdapter(sdk),
+		AuthKey:         authkeyadapter.NewIAMAuthorizedKeyAdapter(sdk),
+		SAKey:           sakeyadapter.NewStaticAccessKeyAdapter(sdk),
+		YCR:             ycradapter.NewYandexContainerRegistryAdapterSDK(sdk),
+		YMQ:             ymqadapter.NewYandexMessageQueueAdapterSDK(),
+		YOS:             yos,
+		YSA:             ysaadapter.NewYandexServiceAccountAdapterSDK(sdk),
+		ServiceAccounts: serviceAccountListerSDK{sdk: sdk},
+		YMQEndpoint:     ymqEndpoint,
+		YOSEndpoint:     yosEndpoint,
+	}, nil
+}
+
+func (p *Plugin) cloud(ctx context.Context) (*Cloud, error) {
+	cloud, err := p.NewCloud(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the cloud: %w", err)
+	}
+	return cloud, nil
+}
+
+func (p *Plugin) requireClusterID() error {
+	if p.ClusterID == "" {
+		return fmt.Errorf("cluster id is required to match cloud resources, set it with --cluster-id")
+	}
+	return nil
+}
+
+func (p *Plugin) get(ctx context.Context, kindName, name string) (*kind, client.Object, error) {
+	k, err := kindByName(kindName)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := k.newObject()
+	if err := p.Client.Get(ctx, client.ObjectKey{Namespace: p.Namespace, Name: name}, obj); err != nil {
+		return nil, nil, fmt.Errorf("unable to get %s %s/%s: %w", k.shortName, p.Namespace, name, err)
+	}
+	return k, obj, nil
+}
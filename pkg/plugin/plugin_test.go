@@ -0,0 +1,443 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/controller/adapter"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ycradapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/controller/adapter"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ymqadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/controller/adapter"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosadapter "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/controller/adapter"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+	k8sfake "github.com/yandex-cloud/k8s-cloud-connectors/testing/k8s-fake"
+)
+
+var now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeServiceAccounts map[string][]*iam.ServiceAccount
+
+func (r fakeServiceAccounts) List(_ context.Context, folderID string) ([]*iam.ServiceAccount, error) {
+	return r[folderID], nil
+}
+
+func setup(t *testing.T) (context.Context, *Plugin, *bytes.Buffer, *Cloud) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	ycrAdapter := ycradapter.NewFakeYandexContainerRegistryAdapter()
+	sakeyAdapter := sakeyadapter.NewFakeStaticAccessKeyAdapter()
+	cloud := &Cloud{
+		SAKey:           &sakeyAdapter,
+		YCR:             &ycrAdapter,
+		YMQ:             ymqadapter.NewFakeYandexMessageQueueAdapter(),
+		YOS:             yosadapter.NewFakeYandexObjectStorageAdapter(),
+		ServiceAccounts: fakeServiceAccounts{},
+		YMQEndpoint:     ymqconfig.DefaultEndpoint,
+		YOSEndpoint:     yosconfig.DefaultEndpoint,
+	}
+	return context.Background(), &Plugin{
+		Client:    k8sfake.NewFakeClient(),
+		Out:       out,
+		Namespace: "default",
+		ClusterID: "cluster",
+		NewCloud: func(_ context.Context) (*Cloud, error) {
+			return cloud, nil
+		},
+		Now: func() time.Time { return now },
+	}, out, cloud
+}
+
+func createRegistry(
+	ctx context.Context, t *testing.T, cl client.Client, name, namespace, id string,
+) *ycr.YandexContainerRegistry {
+	t.Helper()
+	obj := &ycr.YandexContainerRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       ycr.YandexContainerRegistrySpec{Name: name, FolderID: "folder"},
+		Status:     ycr.YandexContainerRegistryStatus{ID: id},
+	}
+	if id != "" {
+		obj.Finalizers = []string{ycrconfig.FinalizerName}
+	}
+	require.NoError(t, cl.Create(ctx, obj))
+	return obj
+}
+
+func createCloudRegistry(
+	ctx context.Context, t *testing.T, ad ycradapter.YandexContainerRegistryAdapter, name string, labeled bool,
+) {
+	t.Helper()
+	request := &containerregistry.CreateRegistryRequest{FolderId: "folder", Name: name}
+	if labeled {
+		request.Labels = map[string]string{
+			config.CloudClusterLabel: "cluster",
+			config.CloudNameLabel:    name,
+		}
+	}
+	_, err := ad.Create(ctx, request)
+	require.NoError(t, err)
+}
+
+func markDeleted(ctx context.Context, t *testing.T, cl client.Client, obj client.Object) {
+	t.Helper()
+	ts := metav1.NewTime(now)
+	obj.SetDeletionTimestamp(&ts)
+	require.NoError(t, cl.Update(ctx, obj))
+}
+
+func TestList(t *testing.T) {
+	t.Run("objects are listed with cloud ids and state", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, _ := setup(t)
+		createRegistry(ctx, t, p.Client, "ready", "default", "id-1")
+		createRegistry(ctx, t, p.Client, "pending", "default", "")
+		markDeleted(ctx, t, p.Client, createRegistry(ctx, t, p.Client, "terminating", "default", "id-2"))
+		paused := &yos.YandexObjectStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "paused", Namespace: "default", Finalizers: []string{yosconfig.FinalizerName},
+			},
+			Spec: yos.YandexObjectStorageSpec{Name: "bucket"},
+			Status: yos.YandexObjectStorageStatus{
+				Conditions: []metav1.Condition{{Type: phase.ConditionPaused, Status: metav1.ConditionTrue}},
+			},
+		}
+		require.NoError(t, p.Client.Create(ctx, paused))
+		createRegistry(ctx, t, p.Client, "elsewhere", "other", "id-3")
+
+		// Act
+		require.NoError(t, p.List(ctx, nil, false))
+
+		// Assert
+		assert.Regexp(t, `ycr\s+default\s+ready\s+id-1\s+Ready`, out.String())
+		assert.Regexp(t, `ycr\s+default\s+pending\s+<none>\s+Pending`, out.String())
+		assert.Regexp(t, `ycr\s+default\s+terminating\s+id-2\s+Terminating`, out.String())
+		assert.Regexp(t, `yos\s+default\s+paused\s+bucket\s+Paused`, out.String())
+		assert.NotContains(t, out.String(), "elsewhere")
+	})
+
+	t.Run("all namespaces are listed on request", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, _ := setup(t)
+		createRegistry(ctx, t, p.Client, "elsewhere", "other", "id-3")
+
+		// Act
+		require.NoError(t, p.List(ctx, []string{"YandexContainerRegistry"}, true))
+
+		// Assert
+		assert.Contains(t, out.String(), "elsewhere")
+	})
+
+	t.Run("unknown kind is an error", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, _ := setup(t)
+
+		// Act
+		err := p.List(ctx, []string{"unknown"}, false)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("cloud state is shown next to spec", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, cloud := setup(t)
+		createCloudRegistry(ctx, t, cloud.YCR, "registry", true)
+		createRegistry(ctx, t, p.Client, "registry", "default", "0")
+
+		// Act
+		require.NoError(t, p.Describe(ctx, "ycr", "registry"))
+
+		// Assert
+		assert.Contains(t, out.String(), "folderId: folder")
+		assert.Contains(t, out.String(), "Cloud:\n")
+		assert.Contains(t, out.String(), `id: "0"`)
+	})
+
+	t.Run("missing cloud resource is reported", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, _ := setup(t)
+		createRegistry(ctx, t, p.Client, "registry", "default", "0")
+
+		// Act
+		require.NoError(t, p.Describe(ctx, "ycr", "registry"))
+
+		// Assert
+		assert.Contains(t, out.String(), "<not found>")
+	})
+
+	t.Run("cloud resource is not matched without cluster id", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, _ := setup(t)
+		p.ClusterID = ""
+		createRegistry(ctx, t, p.Client, "registry", "default", "0")
+
+		// Act
+		err := p.Describe(ctx, "ycr", "registry")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("reconcile annotation is bumped", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, _ := setup(t)
+		createRegistry(ctx, t, p.Client, "registry", "default", "0")
+
+		// Act
+		require.NoError(t, p.Reconcile(ctx, "ycr", "registry"))
+
+		// Assert
+		var obj ycr.YandexContainerRegistry
+		require.NoError(t, p.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "registry"}, &obj))
+		assert.Equal(t, now.Format(time.RFC3339Nano), obj.Annotations[config.ReconcileRequestedAnnotation])
+	})
+}
+
+func TestUnfinalize(t *testing.T) {
+	t.Run("finalizer of object that is not being deleted is kept", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, _ := setup(t)
+		createRegistry(ctx, t, p.Client, "registry", "default", "0")
+
+		// Act
+		err := p.Unfinalize(ctx, "ycr", "registry", true)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("finalizer is kept while cloud resource exists", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, cloud := setup(t)
+		createCloudRegistry(ctx, t, cloud.YCR, "registry", true)
+		markDeleted(ctx, t, p.Client, createRegistry(ctx, t, p.Client, "registry", "default", "0"))
+
+		// Act
+		err := p.Unfinalize(ctx, "ycr", "registry", false)
+
+		// Assert
+		assert.Error(t, err)
+		var obj ycr.YandexContainerRegistry
+		require.NoError(t, p.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "registry"}, &obj))
+		assert.Contains(t, obj.Finalizers, ycrconfig.FinalizerName)
+	})
+
+	t.Run("finalizer is removed when cloud resource is gone", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, _ := setup(t)
+		markDeleted(ctx, t, p.Client, createRegistry(ctx, t, p.Client, "registry", "default", "0"))
+
+		// Act
+		require.NoError(t, p.Unfinalize(ctx, "ycr", "registry", false))
+
+		// Assert
+		var obj ycr.YandexContainerRegistry
+		require.NoError(t, p.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "registry"}, &obj))
+		assert.NotContains(t, obj.Finalizers, ycrconfig.FinalizerName)
+	})
+
+	t.Run("forced removal does not check cloud", func(t *testing.T) {
+		// Arrange
+		ctx, p, _, cloud := setup(t)
+		createCloudRegistry(ctx, t, cloud.YCR, "registry", true)
+		markDeleted(ctx, t, p.Client, createRegistry(ctx, t, p.Client, "registry", "default", "0"))
+
+		// Act
+		require.NoError(t, p.Unfinalize(ctx, "ycr", "registry", true))
+
+		// Assert
+		var obj ycr.YandexContainerRegistry
+		require.NoError(t, p.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "registry"}, &obj))
+		assert.NotContains(t, obj.Finalizers, ycrconfig.FinalizerName)
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("unmanaged resources are exported for adoption", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, cloud := setup(t)
+		createCloudRegistry(ctx, t, cloud.YCR, "Legacy_Registry", false)
+		createCloudRegistry(ctx, t, cloud.YCR, "managed", true)
+		cloud.ServiceAccounts = fakeServiceAccounts{"folder": {{Id: "sa-id", Name: "deployer"}}}
+		key, err := cloud.SAKey.Create(ctx, "sa-id", "")
+		require.NoError(t, err)
+		_, err = cloud.SAKey.Create(ctx, "sa-id", sakeyconfig.GetStaticAccessKeyDescription("cluster", "managed"))
+		require.NoError(t, err)
+		require.NoError(t, cloud.YOS.Create(ctx, nil, "bucket"))
+		createSAKey(ctx, t, p.Client, "exporter")
+
+		// Act
+		require.NoError(t, p.Export(ctx, "folder", "exporter"))
+
+		// Assert
+		manifests := out.String()
+		assert.Contains(t, manifests, "name: legacy-registry\n")
+		assert.Contains(t, manifests, "name: Legacy_Registry\n")
+		assert.Contains(t, manifests, "already managed from cluster cluster")
+		assert.Contains(t, manifests, "kind: StaticAccessKey")
+		assert.Contains(t, manifests, "name: deployer-"+key.AccessKey.Id+"\n")
+		assert.Contains(t, manifests, "name: sakey-deployer-"+key.AccessKey.Id+"-secret")
+		assert.Contains(t, manifests, "kind: YandexObjectStorage")
+		assert.Contains(t, manifests, "SAKeyName: exporter")
+		assert.NotContains(t, manifests, "status:")
+	})
+
+	t.Run("buckets and queues are skipped without key", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, cloud := setup(t)
+		require.NoError(t, cloud.YOS.Create(ctx, nil, "bucket"))
+
+		// Act
+		require.NoError(t, p.Export(ctx, "folder", ""))
+
+		// Assert
+		assert.NotContains(t, out.String(), "kind: YandexObjectStorage")
+		assert.Contains(t, out.String(), "set --sakey")
+	})
+
+	t.Run("resources of all pages are exported", func(t *testing.T) {
+		// Arrange
+		ctx, p, out, _ := setup(t)
+		setupPagedCloud(
+			t, p,
+			[]*containerregistry.Registry{
+				{Id: "first-registry-id", Name: "first-registry", FolderId: "folder"},
+				{Id: "second-registry-id", Name: "second-registry", FolderId: "folder"},
+			},
+			[]*iam.ServiceAccount{{Id: "first-sa-id", Name: "first"}, {Id: "second-sa-id", Name: "second"}},
+			map[string][]*awscompatibility.AccessKey{
+				"first-sa-id":  {{Id: "first-key"}, {Id: "second-key"}},
+				"second-sa-id": {{Id: "third-key"}},
+			},
+		)
+
+		// Act
+		require.NoError(t, p.Export(ctx, "folder", ""))
+
+		// Assert
+		manifests := out.String()
+		assert.Contains(t, manifests, "name: first-registry\n")
+		assert.Contains(t, manifests, "name: second-registry\n")
+		assert.Contains(t, manifests, "name: first-first-key\n")
+		assert.Contains(t, manifests, "name: first-second-key\n")
+		assert.Contains(t, manifests, "name: second-third-key\n")
+	})
+}
+
+func createSAKey(ctx context.Context, t *testing.T, cl client.Client, name string) {
+	t.Helper()
+	require.NoError(t, cl.Create(ctx, &sakey.StaticAccessKey{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     sakey.StaticAccessKeyStatus{SecretName: name + "-secret"},
+	}))
+	require.NoError(t, cl.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-secret", Namespace: "default"},
+		Data:       map[string][]byte{"key": []byte("key"), "secret": []byte("secret")},
+	}))
+}
+
+const terraformState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed", "type": "yandex_storage_bucket", "name": "assets",
+      "instances": [{"attributes": {"bucket": "assets", "acl": "private", "access_key": "AK", "force_destroy": false,
+        "website": [{"index_document": "index.html"}]}}]
+    },
+    {
+      "mode": "managed", "type": "yandex_iam_service_account_static_access_key", "name": "deployer",
+      "instances": [{"attributes": {"id": "key-id", "service_account_id": "sa-id", "access_key": "AK",
+        "secret_key": "SK", "description": "deploys things"}}]
+    },
+    {
+      "mode": "managed", "type": "yandex_container_registry", "name": "registry",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "registry-id", "name": "main", "folder_id": "folder", "labels": {}}},
+        {"index_key": 1, "status": "tainted", "attributes": {"id": "tainted-id"}}
+      ]
+    },
+    {
+      "mode": "managed", "type": "yandex_message_queue", "name": "jobs",
+      "instances": [{"attributes": {"id": "https://queue/jobs", "name": "jobs", "visibility_timeout_seconds": 60,
+        "access_key": "other"}}]
+    },
+    {"mode": "data", "type": "yandex_container_registry", "name": "lookup", "instances": [{"attributes": {}}]},
+    {"mode": "managed", "type": "yandex_compute_instance", "name": "vm", "instances": [{"attributes": {}}]}
+  ]
+}`
+
+func TestImportTerraformState(t *testing.T) {
+	t.Run("managed resources are converted for adoption", func(t *testing.T) {
+		// Arrange
+		_, p, out, _ := setup(t)
+
+		// Act
+		require.NoError(t, p.ImportTerraformState(strings.NewReader(terraformState), ""))
+
+		// Assert
+		manifests := out.String()
+		assert.Contains(t, manifests, "kind: StaticAccessKey")
+		assert.Contains(t, manifests, "name: sakey-deployer-secret\n")
+		assert.Contains(t, manifests, "secret: SK\n")
+		assert.Contains(t, manifests, "Attribute description of yandex_iam_service_account_static_access_key.deployer")
+		assert.Contains(t, manifests, "name: registry-0\n")
+		assert.Contains(t, manifests, `yandex_container_registry.registry["1"] is skipped, it is tainted.`)
+		assert.NotContains(t, manifests, "tainted-id")
+		assert.NotContains(t, manifests, "lookup")
+		assert.Contains(t, manifests, "SAKeyName: deployer\n")
+		assert.Contains(t, manifests, "Attribute website of yandex_storage_bucket.assets")
+		assert.NotContains(t, manifests, "Attribute force_destroy")
+		assert.Contains(t, manifests, "visibilityTimeout: 60\n")
+		assert.Contains(t, manifests, "Access key of yandex_message_queue.jobs is not in the state")
+		assert.Contains(t, manifests, "no connector for yandex_compute_instance")
+	})
+
+	t.Run("key given by flag is used for unknown access keys", func(t *testing.T) {
+		// Arrange
+		_, p, out, _ := setup(t)
+
+		// Act
+		require.NoError(t, p.ImportTerraformState(strings.NewReader(terraformState), "fallback"))
+
+		// Assert
+		assert.Contains(t, out.String(), "SAKeyName: fallback\n")
+		assert.NotContains(t, out.String(), "is not in the state")
+	})
+
+	t.Run("unsupported state version is rejected", func(t *testing.T) {
+		// Arrange
+		_, p, _, _ := setup(t)
+
+		// Act
+		err := p.ImportTerraformState(strings.NewReader(`{"version": 3}`), "")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
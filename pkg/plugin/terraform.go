@@ -0,0 +1,278 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sakey "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/api/v1"
+	sakeyconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/sakey/pkg/config"
+	ycr "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/api/v1"
+	ycrconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ycr/pkg/config"
+	ymq "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/api/v1"
+	ymqconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/ymq/pkg/config"
+	yos "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/api/v1"
+	yosconfig "github.com/yandex-cloud/k8s-cloud-connectors/connector/yos/pkg/config"
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/secret"
+)
+
+const (
+	tfStateVersion = 4
+
+	tfStaticAccessKey = "yandex_iam_service_account_static_access_key"
+	tfRegistry        = "yandex_container_registry"
+	tfBucket          = "yandex_storage_bucket"
+	tfQueue           = "yandex_message_queue"
+)
+
+type tfState struct {
+	Version   int          `json:"version"`
+	Resources []tfResource `json:"resources"`
+}
+
+type tfResource struct {
+	Mode      string       `json:"mode"`
+	Type      string       `json:"type"`
+	Name      string       `json:"name"`
+	Instances []tfInstance `json:"instances"`
+}
+
+type tfInstance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Status     string                 `json:"status"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// tfAttributes keeps track of attributes that were converted, the rest is reported.
+type tfAttributes struct {
+	values map[string]interface{}
+	used   map[string]bool
+}
+
+func (r *tfAttributes) str(name string) string {
+	r.used[name] = true
+	if v, ok := r.values[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (r *tfAttributes) integer(name string) int {
+	r.used[name] = true
+	if v, ok := r.values[name].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+func (r *tfAttributes) boolean(name string) bool {
+	r.used[name] = true
+	v, _ := r.values[name].(bool)
+	return v
+}
+
+// ignore marks attributes that are computed by the cloud or only matter to terraform.
+func (r *tfAttributes) ignore(names ...string) {
+	for _, name := range names {
+		r.used[name] = true
+	}
+}
+
+// unsupported returns attributes that are set, but were not converted.
+func (r *tfAttributes) unsupported() []string {
+	var res []string
+	for name, v := range r.values {
+		if r.used[name] || v == nil || reflect.ValueOf(v).IsZero() {
+			continue
+		}
+		if rv := reflect.ValueOf(v); (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+			continue
+		}
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// tfConversion holds state shared between converted resources: buckets and queues
+// refer to StaticAccessKey objects by access key id they are accessed with.
+type tfConversion struct {
+	w         *manifestWriter
+	sakeyName string
+	keys      map[string]string
+}
+
+// ImportTerraformState converts resources of Yandex Cloud terraform provider to manifests,
+// which make connectors adopt these resources. Attributes that cannot be represented are
+// reported as comments. Buckets and queues are accessed with StaticAccessKey converted from
+// the same state if it has their access key, otherwise with the one given by sakeyName.
+func (p *Plugin) ImportTerraformState(r io.Reader, sakeyName string) error {
+	var state tfState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("unable to decode terraform state: %w", err)
+	}
+	if state.Version != tfStateVersion {
+		return fmt.Errorf("unsupported terraform state version %d, only %d is supported", state.Version, tfStateVersion)
+	}
+
+	c := &tfConversion{
+		w:         &manifestWriter{out: p.Out},
+		sakeyName: sakeyName,
+		keys:      map[string]string{},
+	}
+	converters := map[string]func(c *tfConversion, name, address string, attributes *tfAttributes) error{
+		tfStaticAccessKey: p.convertStaticAccessKey,
+		tfRegistry:        p.convertRegistry,
+		tfBucket:          p.convertBucket,
+		tfQueue:           p.convertQueue,
+	}
+
+	// Keys go first, so that buckets and queues can refer to them
+	for _, types := range [][]string{{tfStaticAccessKey}, {tfRegistry, tfBucket, tfQueue}} {
+		for _, resource := range state.Resources {
+			if resource.Mode != "managed" || !contains(types, resource.Type) {
+				continue
+			}
+			for _, instance := range resource.Instances {
+				name, address := objectName(resource.Name), resource.Type+"."+resource.Name
+				if instance.IndexKey != nil {
+					index := fmt.Sprint(instance.IndexKey)
+					name = objectName(resource.Name, index)
+					address += "[" + strconv.Quote(index) + "]"
+				}
+				if instance.Status != "" {
+					c.w.comment("%s is skipped, it is %s.", address, instance.Status)
+					continue
+				}
+
+				attributes := &tfAttributes{values: instance.Attributes, used: map[string]bool{}}
+				if err := converters[resource.Type](c, name, address, attributes); err != nil {
+					return fmt.Errorf("unable to convert %s: %w", address, err)
+				}
+			}
+		}
+	}
+	for _, resource := range state.Resources {
+		if resource.Mode == "managed" && converters[resource.Type] == nil {
+			c.w.comment("%s.%s is skipped, there is no connector for %s.", resource.Type, resource.Name, resource.Type)
+		}
+	}
+
+	c.w.close()
+	return nil
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *tfConversion) reportUnsupported(address, kindName string, attributes *tfAttributes) {
+	for _, name := range attributes.unsupported() {
+		c.w.comment("Attribute %s of %s cannot be represented by %s and is not managed.", name, address, kindName)
+	}
+}
+
+// keyFor returns name of StaticAccessKey for buckets and queues accessed with the given access key.
+func (c *tfConversion) keyFor(address, accessKey string) string {
+	if name, ok := c.keys[accessKey]; ok {
+		return name
+	}
+	if c.sakeyName == "" {
+		c.w.comment("Access key of %s is not in the state, set SAKeyName or use --sakey.", address)
+	}
+	return c.sakeyName
+}
+
+func (p *Plugin) convertStaticAccessKey(c *tfConversion, name, address string, attributes *tfAttributes) error {
+	attributes.ignore("created_at", "key_fingerprint", "encrypted_secret_key", "pgp_key")
+	accessKey := attributes.str("access_key")
+	secretKey := attributes.str("secret_key")
+	c.keys[accessKey] = name
+	obj := &sakey.StaticAccessKey{
+		TypeMeta:   metav1.TypeMeta{APIVersion: sakey.GroupVersion.String(), Kind: sakeyconfig.LongName},
+		ObjectMeta: p.objectMeta(name, attributes.str("id")),
+		Spec:       sakey.StaticAccessKeySpec{ServiceAccountID: attributes.str("service_account_id")},
+	}
+	c.reportUnsupported(address, sakeyconfig.LongName, attributes)
+	if err := c.w.object(obj); err != nil {
+		return err
+	}
+
+	if secretKey == "" {
+		c.w.comment("Secret key of %s is encrypted with PGP key, fill in the decrypted one.", address)
+	}
+	return c.w.object(&v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name(name, sakeyconfig.ShortName),
+			Namespace: p.Namespace,
+			Labels:    map[string]string{"kind": sakeyconfig.ShortName},
+		},
+		StringData: map[string]string{"key": accessKey, "secret": secretKey},
+	})
+}
+
+func (p *Plugin) convertRegistry(c *tfConversion, name, address string, attributes *tfAttributes) error {
+	attributes.ignore("created_at", "status")
+	obj := &ycr.YandexContainerRegistry{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ycr.GroupVersion.String(), Kind: ycrconfig.LongName},
+		ObjectMeta: p.objectMeta(name, attributes.str("id")),
+		Spec: ycr.YandexContainerRegistrySpec{
+			Name:     attributes.str("name"),
+			FolderID: attributes.str("folder_id"),
+		},
+	}
+	c.reportUnsupported(address, ycrconfig.LongName, attributes)
+	return c.w.object(obj)
+}
+
+func (p *Plugin) convertBucket(c *tfConversion, name, address string, attributes *tfAttributes) error {
+	attributes.ignore("id", "bucket_domain_name", "secret_key", "force_destroy")
+	bucket := attributes.str("bucket")
+	obj := &yos.YandexObjectStorage{
+		TypeMeta:   metav1.TypeMeta{APIVersion: yos.GroupVersion.String(), Kind: yosconfig.LongName},
+		ObjectMeta: p.objectMeta(name, bucket),
+		Spec: yos.YandexObjectStorageSpec{
+			Name:      bucket,
+			ACL:       attributes.str("acl"),
+			SAKeyName: c.keyFor(address, attributes.str("access_key")),
+		},
+	}
+	c.reportUnsupported(address, yosconfig.LongName, attributes)
+	return c.w.object(obj)
+}
+
+func (p *Plugin) convertQueue(c *tfConversion, name, address string, attributes *tfAttributes) error {
+	attributes.ignore("arn", "region", "secret_key")
+	obj := &ymq.YandexMessageQueue{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ymq.GroupVersion.String(), Kind: ymqconfig.LongName},
+		ObjectMeta: p.objectMeta(name, attributes.str("id")),
+		Spec: ymq.YandexMessageQueueSpec{
+			Name:                          attributes.str("name"),
+			FifoQueue:                     attributes.boolean("fifo_queue"),
+			ContentBasedDeduplication:     attributes.boolean("content_based_deduplication"),
+			DelaySeconds:                  attributes.integer("delay_seconds"),
+			MaximumMessageSize:            attributes.integer("max_message_size"),
+			MessageRetentionPeriod:        attributes.integer("message_retention_seconds"),
+			ReceiveMessageWaitTimeSeconds: attributes.integer("receive_wait_time_seconds"),
+			VisibilityTimeout:             attributes.integer("visibility_timeout_seconds"),
+			SAKeyName:                     c.keyFor(address, attributes.str("access_key")),
+		},
+	}
+	c.reportUnsupported(address, ymqconfig.LongName, attributes)
+	return c.w.object(obj)
+}
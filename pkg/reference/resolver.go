@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package reference
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/phase"
+)
+
+// Kind describes objects of one kind that can be referenced
+type Kind struct {
+	// Name: kind of objects, e.g. YandexServiceAccount
+	Name string
+
+	// New: returns empty object of the kind
+	New func() client.Object
+
+	// Status: returns id of cloud resource managed by the object and conditions of the object
+	Status func(obj client.Object) (string, []metav1.Condition)
+}
+
+// Resolve returns id of cloud resource managed by referenced object. Object must be Ready,
+// so that resource it manages can be used by referring object right away.
+func Resolve(
+	ctx context.Context, cl client.Reader, namespace string, ref *ObjectReference, kind Kind,
+) (string, error) {
+	if err := ref.Validate(kind.Name); err != nil {
+		return "", err
+	}
+
+	name := ref.NamespacedName(namespace)
+	obj := kind.New()
+	if err := cl.Get(ctx, name, obj); err != nil {
+		return "", fmt.Errorf("unable to get %s %s: %w", kind.Name, name, err)
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return "", fmt.Errorf("%s %s is being deleted", kind.Name, name)
+	}
+
+	id, conditions := kind.Status(obj)
+	if id == "" || !meta.IsStatusConditionTrue(conditions, phase.ConditionReady) {
+		return "", fmt.Errorf("%s %s is not ready", kind.Name, name)
+	}
+	return id, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+// Package reference lets objects refer to cloud resources by connector objects managing them
+// instead of raw cloud ids, so that manifests are portable across environments.
+// +kubebuilder:object:generate=true
+package reference
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectReference points to connector object managing cloud resource
+type ObjectReference struct {
+	// Kind: kind of referenced object, e.g. YandexServiceAccount
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Name: name of referenced object
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace: namespace of referenced object, namespace of referring object by default
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NamespacedName returns name of referenced object, namespace is the one of referring object.
+func (r *ObjectReference) NamespacedName(namespace string) types.NamespacedName {
+	if r.Namespace != "" {
+		namespace = r.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: r.Name}
+}
+
+// Matches reports whether reference made from namespace points to the object of kind.
+func (r *ObjectReference) Matches(namespace, kind string, obj client.Object) bool {
+	return r.Kind == kind && r.NamespacedName(namespace) == types.NamespacedName{
+		Namespace: obj.GetNamespace(), Name: obj.GetName(),
+	}
+}
+
+// Validate checks that reference points to object of kind.
+func (r *ObjectReference) Validate(kind string) error {
+	if r.Kind != kind {
+		return fmt.Errorf("reference must point to %s, got %s", kind, r.Kind)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("reference to %s must have name", kind)
+	}
+	return nil
+}
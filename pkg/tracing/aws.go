@@ -0,0 +1,40 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package tracing
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentAWS makes every request sent through handlers a span, which carries id of the request.
+// The span starts once the request is built, so that retries are part of it. Requests must be sent
+// with context of the phase, i.e. with WithContext methods of the client.
+func InstrumentAWS(handlers *request.Handlers) {
+	handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: "tracing.Start",
+		Fn: func(r *request.Request) {
+			ctx, _ := Start(r.Context(), r.ClientInfo.ServiceName+"/"+r.Operation.Name,
+				semconv.RPCSystemKey.String("aws-api"),
+				semconv.RPCServiceKey.String(r.ClientInfo.ServiceName),
+				semconv.RPCMethodKey.String(r.Operation.Name),
+			)
+			r.SetContext(ctx)
+		},
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "tracing.End",
+		Fn: func(r *request.Request) {
+			span := trace.SpanFromContext(r.Context())
+			if r.RequestID != "" {
+				span.SetAttributes(RequestIDKey.String(r.RequestID))
+			}
+			if r.HTTPResponse != nil {
+				span.SetAttributes(semconv.HTTPStatusCodeKey.Int(r.HTTPResponse.StatusCode))
+			}
+			End(span, r.Error)
+		},
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/errorhandling"
+)
+
+const (
+	clientRequestIDHeader = "x-client-request-id"
+	requestIDHeader       = "x-request-id"
+)
+
+// DialOptions make every call of Yandex Cloud SDK a span, which carries ids of the request.
+// The ids are attached to errors of calls as well.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), requestIDInterceptor),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	}
+}
+
+// requestIDInterceptor runs inside of the call span, so that both the span and the error get ids of the request.
+func requestIDInterceptor(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	span := trace.SpanFromContext(ctx)
+
+	clientRequestID := uuid.New().String()
+	ctx = metadata.AppendToOutgoingContext(ctx, clientRequestIDHeader, clientRequestID)
+	span.SetAttributes(ClientRequestIDKey.String(clientRequestID))
+
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	var requestID string
+	if ids := header.Get(requestIDHeader); len(ids) != 0 {
+		requestID = ids[0]
+		span.SetAttributes(RequestIDKey.String(requestID))
+	}
+	return errorhandling.WithRequestID(err, requestID, clientRequestID)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	instrumentationName = "github.com/yandex-cloud/k8s-cloud-connectors"
+
+	// RequestIDKey is the attribute holding ID the cloud assigned to the request, it is what support asks for.
+	RequestIDKey = attribute.Key("cloud.request_id")
+	// ClientRequestIDKey is the attribute holding ID the request was sent with.
+	ClientRequestIDKey = attribute.Key("cloud.client_request_id")
+
+	kindKey      = attribute.Key("k8s.object.kind")
+	namespaceKey = attribute.Key("k8s.namespace.name")
+	nameKey      = attribute.Key("k8s.object.name")
+)
+
+// Config tells where traces are exported to.
+type Config struct {
+	// Endpoint is the address of OTLP gRPC collector, traces are not exported if it is empty.
+	Endpoint string
+	Insecure bool
+	// SampleRatio is the share of traces started by the manager that are recorded.
+	SampleRatio float64
+	// ServiceName identifies the process in traces.
+	ServiceName string
+}
+
+// Setup installs global tracer provider exporting spans over OTLP, it does nothing if endpoint is not set.
+// Returned function flushes the spans that are not exported yet and must be called on exit.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start starts span of the named reconciliation phase, the span must be finished with End.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records error of the phase, if any, and finishes its span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type reconciler struct {
+	kind string
+	next reconcile.Reconciler
+}
+
+// Reconciler wraps reconciler of the given kind, so that every reconciliation gets a span
+// which phases and cloud calls are children of.
+func Reconciler(kind string, next reconcile.Reconciler) reconcile.Reconciler {
+	return &reconciler{kind: kind, next: next}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := Start(ctx, "reconcile "+r.kind,
+		kindKey.String(r.kind), namespaceKey.String(req.Namespace), nameKey.String(req.Name),
+	)
+	res, err := r.next.Reconcile(ctx, req)
+	End(span, err)
+	return res, err
+}
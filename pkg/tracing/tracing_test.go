@@ -0,0 +1,91 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestReconciler(t *testing.T) {
+	t.Run("phases are children of reconciliation span", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+		r := Reconciler("Kind", reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+			_, span := Start(ctx, "allocate-resource")
+			End(span, fmt.Errorf("cloud is unavailable"))
+			return ctrl.Result{}, fmt.Errorf("unable to allocate resource")
+		}))
+
+		// Act
+		_, err := r.Reconcile(context.Background(), ctrl.Request{})
+
+		// Assert
+		require.Error(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "allocate-resource", spans[0].Name())
+		assert.Equal(t, "reconcile Kind", spans[1].Name())
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	})
+}
+
+func TestInstrumentAWS(t *testing.T) {
+	t.Run("request gets span with request id", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-amzn-RequestId", "request-id")
+			_, _ = fmt.Fprint(w, `<ListQueuesResponse><ListQueuesResult></ListQueuesResult></ListQueuesResponse>`)
+		}))
+		defer server.Close()
+
+		ses, err := session.NewSession(&aws.Config{
+			Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+			Endpoint:    aws.String(server.URL),
+			Region:      aws.String("ru-central1"),
+		})
+		require.NoError(t, err)
+		InstrumentAWS(&ses.Handlers)
+		ctx, parent := Start(context.Background(), "match-spec")
+
+		// Act
+		_, err = sqs.New(ses).ListQueuesWithContext(ctx, &sqs.ListQueuesInput{})
+		parent.End()
+
+		// Assert
+		require.NoError(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "sqs/ListQueues", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Contains(t, spans[0].Attributes(), RequestIDKey.String("request-id"))
+	})
+}
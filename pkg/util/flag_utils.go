@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArgList is a flag.Value that collects every occurrence of a repeated flag,
+// each occurrence may also hold several comma-separated values.
+type ArgList []string
+
+func (r *ArgList) String() string {
+	return fmt.Sprintf("%v", *r)
+}
+
+func (r *ArgList) Set(val string) error {
+	for _, v := range strings.Split(val, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*r = append(*r, v)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@
 package util
 
 import (
+	"strconv"
+
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -14,3 +16,13 @@ func NamespacedName(obj client.Object) types.NamespacedName {
 		Name:      obj.GetName(),
 	}
 }
+
+// AnnotationIsSet reports whether object has the annotation with true boolean value.
+func AnnotationIsSet(obj client.Object, annotation string) bool {
+	value, ok := obj.GetAnnotations()[annotation]
+	if !ok {
+		return false
+	}
+	set, err := strconv.ParseBool(value)
+	return err == nil && set
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 Yandex LLC. All rights reserved.
+// Author: Martynov Pavel <covariance@yandex-team.ru>
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/yandex-cloud/k8s-cloud-connectors/pkg/util"
+)
+
+// namespaceFilter admits objects from namespaces that are not watched by this manager
+// without validation, they are served by another manager instance.
+type namespaceFilter struct {
+	validator  Validator
+	namespaces []string
+}
+
+// ScopedToNamespaces restricts validator to objects from given namespaces. Validator is
+// returned as is if namespaces are empty, which means that all namespaces are watched.
+func ScopedToNamespaces(validator Validator, namespaces []string) Validator {
+	if len(namespaces) == 0 {
+		return validator
+	}
+	return &namespaceFilter{validator: validator, namespaces: namespaces}
+}
+
+func (r *namespaceFilter) ValidateCreation(ctx context.Context, log logr.Logger, obj runtime.Object) error {
+	watched, err := r.isWatched(obj)
+	if err != nil || !watched {
+		return err
+	}
+	return r.validator.ValidateCreation(ctx, log, obj)
+}
+
+func (r *namespaceFilter) ValidateUpdate(ctx context.Context, log logr.Logger, current, old runtime.Object) error {
+	watched, err := r.isWatched(current)
+	if err != nil || !watched {
+		return err
+	}
+	return r.validator.ValidateUpdate(ctx, log, current, old)
+}
+
+func (r *namespaceFilter) ValidateDeletion(ctx context.Context, log logr.Logger, obj runtime.Object) error {
+	watched, err := r.isWatched(obj)
+	if err != nil || !watched {
+		return err
+	}
+	return r.validator.ValidateDeletion(ctx, log, obj)
+}
+
+func (r *namespaceFilter) isWatched(obj runtime.Object) (bool, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false, fmt.Errorf("unable to access object metadata: %w", err)
+	}
+	return util.ContainsString(r.namespaces, accessor.GetNamespace()), nil
+}
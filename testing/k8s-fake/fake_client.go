@@ -5,11 +5,15 @@ package k8sfake
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/jinzhu/copier"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -67,8 +71,34 @@ func (r *FakeClient) Get(_ context.Context, key client.ObjectKey, obj client.Obj
 // successful call, Items field in the list will be populated with the
 // result returned from the server.
 func (r *FakeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-	// TODO (covariance) implement me!
-	panic("not implemented")
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	items := reflect.ValueOf(list).Elem().FieldByName("Items")
+	if !items.IsValid() {
+		return fmt.Errorf("list %T does not have items", list)
+	}
+	itemType := items.Type().Elem()
+
+	var res []runtime.Object
+	for _, obj := range r.objects {
+		if reflect.TypeOf(obj).Elem() != itemType {
+			continue
+		}
+		if listOpts.Namespace != "" && obj.GetNamespace() != listOpts.Namespace {
+			continue
+		}
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		res = append(res, obj.DeepCopyObject())
+	}
+
+	// Map iteration order is random, but listing must be stable
+	sort.Slice(res, func(i, j int) bool {
+		return util.NamespacedName(res[i].(client.Object)).String() < util.NamespacedName(res[j].(client.Object)).String()
+	})
+	return meta.SetList(list, res)
 }
 
 // Create saves the object obj in the Kubernetes cluster.
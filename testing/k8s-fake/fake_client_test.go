@@ -144,3 +144,45 @@ func TestUpdate(t *testing.T) {
 	// Assert
 	assert.Equal(t, *updSecret, res)
 }
+
+func TestList(t *testing.T) {
+	t.Run("list returns objects of the list type only", func(t *testing.T) {
+		// Arrange
+		c := NewFakeClient()
+		ctx := context.Background()
+		for _, name := range []string{"b", "a"} {
+			require.NoError(t, c.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}))
+		}
+		require.NoError(t, c.Create(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "default"}}))
+
+		// Act
+		var res v1.SecretList
+		require.NoError(t, c.List(ctx, &res))
+
+		// Assert
+		require.Len(t, res.Items, 2)
+		assert.Equal(t, "a", res.Items[0].Name)
+		assert.Equal(t, "b", res.Items[1].Name)
+	})
+
+	t.Run("list respects namespace and labels", func(t *testing.T) {
+		// Arrange
+		c := NewFakeClient()
+		ctx := context.Background()
+		require.NoError(t, c.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "a", Namespace: "default", Labels: map[string]string{"app": "test"},
+		}}))
+		require.NoError(t, c.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"}}))
+		require.NoError(t, c.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "c", Namespace: "other", Labels: map[string]string{"app": "test"},
+		}}))
+
+		// Act
+		var res v1.SecretList
+		require.NoError(t, c.List(ctx, &res, client.InNamespace("default"), client.MatchingLabels{"app": "test"}))
+
+		// Assert
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, "a", res.Items[0].Name)
+	})
+}